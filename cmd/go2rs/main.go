@@ -0,0 +1,396 @@
+// Package main is the go2rs command which generates Rust types from Go packages
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drewstone/go2rs/pkg/config"
+	"github.com/drewstone/go2rs/pkg/generator"
+	"github.com/drewstone/go2rs/pkg/loader"
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"github.com/drewstone/go2rs/pkg/util"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const usage = `Usage: go2rs [flags] [package dir...]
+       go2rs watch [flags] [package dir...]
+
+Generates Rust types from the exported types of the Go packages in the package dirs.
+Settings are read from go2rs.yaml or go2rs.toml in the current directory or its parents,
+and the flags take precedence over them. Without package dirs, the packages in the
+configuration file are used.
+
+With -check, nothing is written. The output is compared with the files on disk instead,
+and go2rs exits with a non-zero status printing a diff when they are out of date.
+
+go2rs watch polls the Go files of the packages and regenerates the output when they change.
+
+Flags:
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "go2rs: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// altPkgsFlag collects repeated -alt package=Name flags
+type altPkgsFlag map[string]string
+
+func (a altPkgsFlag) String() string {
+	pairs := make([]string, 0, len(a))
+	for pkg, name := range a {
+		pairs = append(pairs, pkg+"="+name)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (a altPkgsFlag) Set(s string) error {
+	pkg, name, ok := strings.Cut(s, "=")
+	if !ok || pkg == "" || name == "" {
+		return fmt.Errorf("expected package=Name, got %q", s)
+	}
+	a[pkg] = name
+	return nil
+}
+
+// options are the settings of a run from the flags and the configuration file
+type options struct {
+	configPath  string
+	output      string
+	layout      string
+	moduleRoot  string
+	basePackage string
+	all         bool
+	altPkgs     altPkgsFlag
+
+	dirs []string
+	cfg  *config.Config
+}
+
+// register defines the flags shared by all modes in fs
+func (o *options) register(fs *flag.FlagSet) {
+	o.altPkgs = altPkgsFlag{}
+	fs.StringVar(&o.configPath, "config", "", "configuration file (default: go2rs.yaml or go2rs.toml found from the current directory)")
+	fs.StringVar(&o.output, "o", "", "output file, or directory with -layout files (default stdout)")
+	fs.StringVar(&o.layout, "layout", "flat", "output layout: flat, modules (one inline module per Go package) or files (one file per module)")
+	fs.StringVar(&o.moduleRoot, "module-root", "", "path of the root module in references across modules (default crate)")
+	fs.StringVar(&o.basePackage, "base", "", "base package whose types keep their plain names (default: the loaded package)")
+	fs.BoolVar(&o.all, "all", false, "also generate unexported types and types outside the base package")
+	fs.Var(o.altPkgs, "alt", "alternative name for a package as package=Name, used as the type prefix or module name (repeatable)")
+}
+
+// resolve loads the configuration file after fs is parsed.
+// Flags set explicitly take precedence over the configuration file.
+func (o *options) resolve(fs *flag.FlagSet) error {
+	cfg, err := loadConfig(o.configPath)
+	if err != nil {
+		return err
+	}
+	o.cfg = cfg
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	if !set["o"] {
+		o.output = cfg.OutputPath()
+	}
+	if !set["layout"] && cfg.Layout != "" {
+		o.layout = cfg.Layout
+	}
+	if !set["all"] {
+		o.all = cfg.All
+	}
+
+	switch o.layout {
+	case "flat", "modules":
+	case "files":
+		if o.output == "" {
+			return fmt.Errorf("-layout files requires -o with an output directory")
+		}
+	default:
+		return fmt.Errorf("unknown layout: %s", o.layout)
+	}
+
+	o.dirs = fs.Args()
+	if len(o.dirs) == 0 {
+		o.dirs = cfg.PackageDirs()
+	}
+	if len(o.dirs) == 0 {
+		fs.Usage()
+		return fmt.Errorf("expected package directories in the arguments or the configuration file")
+	}
+
+	return nil
+}
+
+// filter returns the loader filter for the types to load
+func (o *options) filter() func(*loader.FilterOpt) bool {
+	if o.all {
+		return loader.All
+	}
+
+	return loader.Default
+}
+
+// pkgState is a loaded package
+type pkgState struct {
+	dir   string
+	base  string
+	hash  string
+	types map[string]rstypes.Type
+}
+
+// loadPackage loads the package in dir
+func (o *options) loadPackage(dir string) (*pkgState, error) {
+	l, err := loader.NewLoader(dir, o.filter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package %s: %w", dir, err)
+	}
+
+	types, err := l.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse package %s: %w", dir, err)
+	}
+
+	hash, err := l.SourceHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash package %s: %w", dir, err)
+	}
+
+	return &pkgState{
+		dir:   dir,
+		base:  l.GetBasePackage(),
+		hash:  hash,
+		types: types,
+	}, nil
+}
+
+// generate generates the output files from pkgs and writes the diagnostics to log.
+// The files map the output paths onto the generated code, and the path is "" for stdout.
+func (o *options) generate(pkgs []*pkgState, log io.Writer) (map[string]string, error) {
+	types := make(map[string]rstypes.Type)
+	hashes := make([]string, 0, len(pkgs))
+	for _, p := range pkgs {
+		for name, typ := range p.types {
+			types[name] = typ
+		}
+		hashes = append(hashes, p.hash)
+	}
+
+	g := generator.NewGenerator(types)
+	g.BasePackage = pkgs[0].base
+	o.cfg.Apply(g)
+
+	if o.basePackage != "" {
+		g.BasePackage = o.basePackage
+	}
+	for pkg, name := range o.altPkgs {
+		g.SetAltPackage(pkg, name)
+	}
+	if o.moduleRoot != "" {
+		g.ModuleRoot = o.moduleRoot
+	}
+
+	files := make(map[string]string)
+	// sources are the hashes of the packages each file is generated from, when it is not all of them
+	sources := make(map[string][]string)
+	var err error
+	switch o.layout {
+	case "files":
+		g.Layout = generator.LayoutModules
+
+		// Files are stamped with the hashes of their own packages, so editing one package leaves the others' files alone
+		for _, p := range pkgs {
+			path := filepath.Join(o.output, filepath.FromSlash(g.PackageFile(p.base)))
+			sources[path] = append(sources[path], p.hash)
+		}
+
+		var generated map[string]string
+		generated, err = g.GenerateFiles()
+		for name, content := range generated {
+			files[filepath.Join(o.output, filepath.FromSlash(name))] = content
+		}
+	case "modules":
+		g.Layout = generator.LayoutModules
+		files[o.output], err = g.Generate()
+	default:
+		g.Layout = generator.LayoutFlat
+		files[o.output], err = g.Generate()
+	}
+
+	for _, d := range g.Diagnostics() {
+		fmt.Fprintf(log, "go2rs: %s: %s\n", d.Severity, d)
+	}
+
+	var diagErr generator.DiagnosticsError
+	if errors.As(err, &diagErr) {
+		return nil, fmt.Errorf("%d errors in the generated types", len(diagErr))
+	}
+
+	// Files without types of their own, like parent modules, depend on all the packages
+	for path, content := range files {
+		fileHashes := sources[path]
+		if len(fileHashes) == 0 {
+			fileHashes = hashes
+		}
+		files[path] = header(util.SHA1(strings.Join(fileHashes, "\n"))) + content
+	}
+
+	return files, err
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) != 0 && args[0] == "watch" {
+		return runWatch(ctx, args[1:], stderr)
+	}
+
+	fs := flag.NewFlagSet("go2rs", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	opts := &options{}
+	opts.register(fs)
+	check := fs.Bool("check", false, "check that the output files are up to date instead of writing them")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := opts.resolve(fs); err != nil {
+		return err
+	}
+
+	pkgs := make([]*pkgState, 0, len(opts.dirs))
+	for _, dir := range opts.dirs {
+		p, err := opts.loadPackage(dir)
+		if err != nil {
+			return err
+		}
+		pkgs = append(pkgs, p)
+	}
+
+	files, err := opts.generate(pkgs, stderr)
+	if err != nil {
+		return err
+	}
+
+	if *check {
+		if opts.output == "" {
+			return fmt.Errorf("-check requires -o with the output to compare with")
+		}
+
+		return checkFiles(files, stdout)
+	}
+
+	if opts.output == "" {
+		_, err := io.WriteString(stdout, files[""])
+		return err
+	}
+
+	_, err = writeFiles(files)
+	return err
+}
+
+// header returns the header stamped on generated files with the hash of the input packages
+func header(hash string) string {
+	return fmt.Sprintf("// Code generated by go2rs. DO NOT EDIT.\n// Source hash: %s\n\n", hash)
+}
+
+// writeFiles writes the generated files whose contents differ from the files on disk
+// and returns the paths written
+func writeFiles(files map[string]string) ([]string, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	written := make([]string, 0)
+	for _, path := range paths {
+		if b, err := os.ReadFile(path); err == nil && string(b) == files[path] {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, []byte(files[path]), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// checkFiles compares the generated files with the files on disk and writes unified diffs of the stale ones to w
+func checkFiles(files map[string]string, w io.Writer) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	stale := 0
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if string(b) == files[path] {
+			continue
+		}
+		stale++
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(b)),
+			B:        difflib.SplitLines(files[path]),
+			FromFile: path,
+			ToFile:   path + " (generated)",
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return err
+		}
+	}
+
+	if stale != 0 {
+		return fmt.Errorf("%d of %d files are out of date, run go2rs to regenerate them", stale, len(files))
+	}
+
+	return nil
+}
+
+// loadConfig loads the configuration file in path, or the one found from the current directory.
+// An empty configuration is returned when none is found.
+func loadConfig(path string) (*config.Config, error) {
+	if path != "" {
+		return config.Load(path)
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if errors.Is(err, config.ErrNotFound) {
+		return &config.Config{}, nil
+	}
+
+	return cfg, err
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := run(context.Background(), []string{"../../example"}, stdout, stderr); err != nil {
+		t.Fatalf("run() failed: %+v\n%s", err, stderr)
+	}
+
+	got := stdout.String()
+	if !strings.HasPrefix(got, "// Code generated by go2rs. DO NOT EDIT.\n// Source hash: ") {
+		t.Errorf("run() output has no header: %s", got)
+	}
+	for _, want := range []string{
+		"pub enum Status {\n",
+		"pub struct Param {\n",
+		"\tpub created_at: DateTime<Utc>,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("run() output = %s, want to contain %q", got, want)
+		}
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "no packages", args: []string{}, want: "expected package directories"},
+		{name: "unknown layout", args: []string{"-layout", "tree", "../../example"}, want: "unknown layout: tree"},
+		{name: "files without output", args: []string{"-layout", "files", "../../example"}, want: "-layout files requires -o"},
+		{name: "check without output", args: []string{"-check", "../../example"}, want: "-check requires -o"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), tt.args, &bytes.Buffer{}, &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("run() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// writeModule writes a Go module with the files in dir
+func writeModule(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	files["go.mod"] = "module example.com/api\n\ngo 1.23\n"
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRun_Check(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, map[string]string{
+		"order.go":           "package api\n\ntype Order struct {\n\tID string\n}\n",
+		"billing/invoice.go": "package billing\n\ntype Invoice struct {\n\tTotal int64\n}\n",
+	})
+
+	out := filepath.Join(dir, "rust")
+	args := []string{"-layout", "files", "-o", out, dir, filepath.Join(dir, "billing")}
+	check := append([]string{"-check"}, args...)
+
+	if err := run(context.Background(), args, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run() failed: %+v", err)
+	}
+	root, err := os.ReadFile(filepath.Join(out, "mod.rs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := &bytes.Buffer{}
+	if err := run(context.Background(), check, stdout, &bytes.Buffer{}); err != nil || stdout.Len() != 0 {
+		t.Fatalf("run(-check) = %v with %q, want the files to be up to date", err, stdout)
+	}
+
+	// Only the file generated from the edited package is stale
+	writeModule(t, dir, map[string]string{
+		"billing/invoice.go": "package billing\n\ntype Invoice struct {\n\tTotal int64\n\tPaid  bool\n}\n",
+	})
+
+	stdout.Reset()
+	err = run(context.Background(), check, stdout, &bytes.Buffer{})
+	if err == nil || err.Error() != "1 of 2 files are out of date, run go2rs to regenerate them" {
+		t.Errorf("run(-check) error = %v, want 1 stale file", err)
+	}
+
+	invoice := filepath.Join(out, "billing", "mod.rs")
+	diff := stdout.String()
+	for _, want := range []string{
+		"--- " + invoice + "\n",
+		"+++ " + invoice + " (generated)\n",
+		"-// Source hash: ",
+		"+// Source hash: ",
+		"+\tpub paid: bool,\n",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("run(-check) diff = %s, want to contain %q", diff, want)
+		}
+	}
+	if strings.Contains(diff, filepath.Join(out, "mod.rs")) {
+		t.Errorf("run(-check) diff = %s, want mod.rs to be up to date", diff)
+	}
+
+	if err := run(context.Background(), args, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run() failed: %+v", err)
+	}
+	if b, err := os.ReadFile(filepath.Join(out, "mod.rs")); err != nil || string(b) != string(root) {
+		t.Errorf("mod.rs was rewritten: %s", b)
+	}
+	if err := run(context.Background(), check, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Errorf("run(-check) = %v after regenerating, want nil", err)
+	}
+}
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"github.com/drewstone/go2rs/pkg/util"
+)
+
+// fileStamp identifies the version of a file without reading it
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher keeps the loaded packages in memory and regenerates the output when their files change
+type watcher struct {
+	opts   *options
+	pkgs   []*pkgState
+	stamps []map[string]fileStamp
+	log    io.Writer
+
+	interval time.Duration
+	debounce time.Duration
+}
+
+func runWatch(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("go2rs watch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	opts := &options{}
+	opts.register(fs)
+	interval := fs.Duration("interval", 500*time.Millisecond, "interval between polls of the Go files")
+	debounce := fs.Duration("debounce", 300*time.Millisecond, "time without changes to wait for before regenerating")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := opts.resolve(fs); err != nil {
+		return err
+	}
+	if opts.output == "" {
+		return fmt.Errorf("watch requires -o with the output to write")
+	}
+
+	w := &watcher{
+		opts:     opts,
+		log:      stderr,
+		interval: *interval,
+		debounce: *debounce,
+	}
+
+	return w.run(ctx)
+}
+
+// run loads all packages, generates the output, and regenerates it on every change until ctx is done
+func (w *watcher) run(ctx context.Context) error {
+	for _, dir := range w.opts.dirs {
+		stamps, err := snapshot(dir)
+		if err != nil {
+			return err
+		}
+
+		p, err := w.opts.loadPackage(dir)
+		if err != nil {
+			return err
+		}
+
+		w.pkgs = append(w.pkgs, p)
+		w.stamps = append(w.stamps, stamps)
+	}
+
+	if err := w.write(); err != nil {
+		return err
+	}
+	w.logf("watching %s", strings.Join(w.opts.dirs, ", "))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	pending := make(map[int]bool)
+	var lastChange time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		for i, p := range w.pkgs {
+			stamps, err := snapshot(p.dir)
+			if err != nil {
+				w.logf("%s: %v", p.dir, err)
+				continue
+			}
+
+			if !sameStamps(stamps, w.stamps[i]) {
+				w.stamps[i] = stamps
+				pending[i] = true
+				lastChange = time.Now()
+			}
+		}
+
+		// Wait for the changes to settle, e.g. while an editor saves several files
+		if len(pending) == 0 || time.Since(lastChange) < w.debounce {
+			continue
+		}
+
+		w.reload(pending)
+		pending = make(map[int]bool)
+	}
+}
+
+// reload re-parses the pending packages and writes the output when it changed.
+// A package which fails to load keeps its previous types until it is fixed.
+func (w *watcher) reload(pending map[int]bool) {
+	reloaded := false
+	for i := range w.pkgs {
+		if !pending[i] {
+			continue
+		}
+
+		old := w.pkgs[i]
+		p, err := w.opts.loadPackage(old.dir)
+		if err != nil {
+			w.logf("%v", err)
+			continue
+		}
+
+		w.pkgs[i] = p
+		reloaded = true
+		w.logf("%s: %s", p.dir, describeChanges(old.types, p.types))
+	}
+
+	if !reloaded {
+		return
+	}
+
+	if err := w.write(); err != nil {
+		w.logf("%v", err)
+	}
+}
+
+// write generates the output and writes the files whose contents changed
+func (w *watcher) write() error {
+	files, err := w.opts.generate(w.pkgs, w.log)
+	if err != nil {
+		return err
+	}
+
+	written, err := writeFiles(files)
+
+	for _, path := range written {
+		w.logf("wrote %s", path)
+	}
+	if err == nil && len(written) == 0 {
+		w.logf("output is up to date")
+	}
+
+	return err
+}
+
+func (w *watcher) logf(format string, args ...interface{}) {
+	fmt.Fprintf(w.log, "go2rs: %s %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// snapshot returns the stamps of the Go files in dir and the go.mod of its module
+func snapshot(dir string) (map[string]fileStamp, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries)+1)
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".go") {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	if goMod, err := util.GetGoModPath(dir); err == nil {
+		paths = append(paths, goMod)
+	}
+
+	stamps := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			// Removed between ReadDir and Stat, so the next poll will notice it
+			continue
+		}
+
+		stamps[path] = fileStamp{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+	}
+
+	return stamps, nil
+}
+
+func sameStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for path, stamp := range a {
+		other, ok := b[path]
+		if !ok || !stamp.modTime.Equal(other.modTime) || stamp.size != other.size {
+			return false
+		}
+	}
+
+	return true
+}
+
+// describeChanges lists the types added, removed and changed from old to cur
+func describeChanges(old, cur map[string]rstypes.Type) string {
+	var added, removed, changed []string
+
+	for name, t := range cur {
+		prev, ok := old[name]
+		switch {
+		case !ok:
+			added = append(added, shortName(name))
+		case fingerprint(prev) != fingerprint(t):
+			changed = append(changed, shortName(name))
+		}
+	}
+	for name := range old {
+		if _, ok := cur[name]; !ok {
+			removed = append(removed, shortName(name))
+		}
+	}
+
+	parts := make([]string, 0, 3)
+	for _, group := range []struct {
+		label string
+		names []string
+	}{
+		{"added", added},
+		{"removed", removed},
+		{"changed", changed},
+	} {
+		if len(group.names) == 0 {
+			continue
+		}
+
+		sort.Strings(group.names)
+		parts = append(parts, group.label+" "+strings.Join(group.names, ", "))
+	}
+
+	if len(parts) == 0 {
+		return "no type changes"
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// shortName returns the name of a type without its package
+func shortName(name string) string {
+	if !strings.Contains(name, ".") {
+		return name
+	}
+
+	_, short := util.SplitPackageStruct(name)
+
+	return short
+}
+
+// fingerprint returns a description of the shape of t, ignoring source positions.
+// Named types are only described by their names inside other types.
+func fingerprint(t rstypes.Type) string {
+	var buf strings.Builder
+	writeFingerprint(&buf, t, true)
+
+	return buf.String()
+}
+
+func writeFingerprint(buf *strings.Builder, t rstypes.Type, top bool) {
+	switch v := t.(type) {
+	case *rstypes.Struct:
+		if v.Name != "" && !top {
+			buf.WriteString(v.Name)
+			for _, arg := range v.TypeArgs {
+				buf.WriteString(",")
+				writeFingerprint(buf, arg, false)
+			}
+			return
+		}
+
+		fields := make([]string, 0, len(v.Fields))
+		for name := range v.Fields {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+
+		buf.WriteString("struct")
+		for _, p := range v.TypeParams {
+			fmt.Fprintf(buf, "[%s %s]", p.Name, p.Constraint)
+		}
+		buf.WriteString("{")
+		for _, name := range fields {
+			f := v.Fields[name]
+			fmt.Fprintf(buf, "%s %v %v ", name, f.Optional, f.Embedded)
+			writeFingerprint(buf, f.Type, false)
+			buf.WriteString(";")
+		}
+		buf.WriteString("}")
+	case *rstypes.String:
+		fmt.Fprintf(buf, "string(%s)%v", v.Name, v.Enum)
+	case *rstypes.Number:
+		fmt.Fprintf(buf, "number(%s)%d%v%v%d%v", v.Name, v.RawType, v.IsFloat, v.IsSigned, v.BitSize, v.Enum)
+	case *rstypes.Nullable:
+		buf.WriteString("?")
+		writeFingerprint(buf, v.Inner, false)
+	case *rstypes.Array:
+		fmt.Fprintf(buf, "[%d]", v.Size)
+		writeFingerprint(buf, v.Inner, false)
+	case *rstypes.Tuple:
+		if v.Name != "" && !top {
+			buf.WriteString(v.Name)
+			return
+		}
+
+		buf.WriteString("tuple(")
+		for _, elem := range v.Types {
+			writeFingerprint(buf, elem, false)
+			buf.WriteString(",")
+		}
+		buf.WriteString(")")
+	case *rstypes.Trait:
+		if !top {
+			buf.WriteString(v.Name)
+			return
+		}
+
+		buf.WriteString("trait{")
+		for _, super := range v.Supertraits {
+			fmt.Fprintf(buf, "%s;", super.Name)
+		}
+		for _, m := range v.Methods {
+			writeFingerprint(buf, m, false)
+			buf.WriteString(";")
+		}
+		buf.WriteString("}")
+	case *rstypes.Function:
+		fmt.Fprintf(buf, "fn %s %v %v(", v.Name, v.IsMethod, v.IsAsync)
+		for _, p := range v.Params {
+			buf.WriteString(p.Name + " ")
+			writeFingerprint(buf, p.Type, false)
+			buf.WriteString(",")
+		}
+		buf.WriteString(")")
+		writeFingerprint(buf, v.Returns, false)
+	case *rstypes.Enum:
+		if v.Name != "" && !top {
+			buf.WriteString(v.Name)
+			return
+		}
+
+		variants := make([]string, 0, len(v.Variants))
+		for name := range v.Variants {
+			variants = append(variants, name)
+		}
+		sort.Strings(variants)
+
+		fmt.Fprintf(buf, "enum(%d %s %s){", v.Tagging, v.Tag, v.Content)
+		for _, name := range variants {
+			variant := v.Variants[name]
+			fmt.Fprintf(buf, "%s %q %d ", name, variant.RawTag, variant.FieldIndex)
+			writeFingerprint(buf, variant.Type, false)
+			buf.WriteString(";")
+		}
+		buf.WriteString("}")
+	case *rstypes.Stream:
+		fmt.Fprintf(buf, "stream(%d)", v.Direction)
+		writeFingerprint(buf, v.Inner, false)
+	case *rstypes.Result:
+		buf.WriteString("result(")
+		writeFingerprint(buf, v.Ok, false)
+		buf.WriteString(",")
+		writeFingerprint(buf, v.Err, false)
+		buf.WriteString(")")
+	case *rstypes.TypeParam:
+		buf.WriteString("param " + v.Name)
+	case *rstypes.Vec:
+		buf.WriteString("[]")
+		writeFingerprint(buf, v.Inner, false)
+	case *rstypes.Primitive:
+		buf.WriteString("primitive " + v.Name)
+	case *rstypes.Map:
+		buf.WriteString("map[")
+		writeFingerprint(buf, v.Key, false)
+		buf.WriteString("]")
+		writeFingerprint(buf, v.Value, false)
+	default:
+		fmt.Fprintf(buf, "%T", t)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+func TestDescribeChanges(t *testing.T) {
+	old := map[string]rstypes.Type{
+		"example.com/api.A": &rstypes.Struct{
+			Name:   "example.com/api.A",
+			Fields: map[string]rstypes.StructField{"X": {Type: &rstypes.Number{}}},
+		},
+		"example.com/api.B": &rstypes.Struct{Name: "example.com/api.B"},
+		"example.com/api.D": &rstypes.String{Name: "example.com/api.D", Enum: []string{"x"}},
+	}
+	cur := map[string]rstypes.Type{
+		"example.com/api.A": &rstypes.Struct{
+			Name:   "example.com/api.A",
+			Fields: map[string]rstypes.StructField{"X": {Type: &rstypes.String{}}},
+		},
+		"example.com/api.C": &rstypes.Struct{Name: "example.com/api.C"},
+		"example.com/api.D": &rstypes.String{Name: "example.com/api.D", Enum: []string{"x"}},
+	}
+
+	want := "added C; removed B; changed A"
+	if got := describeChanges(old, cur); got != want {
+		t.Errorf("describeChanges() = %q, want %q", got, want)
+	}
+
+	// Changes inside slices, enums, methods and primitives are changes of the types holding them
+	tags := func(inner rstypes.Type) map[string]rstypes.Type {
+		return map[string]rstypes.Type{
+			"example.com/api.Item": &rstypes.Struct{
+				Name: "example.com/api.Item",
+				Fields: map[string]rstypes.StructField{
+					"Tags": {Type: &rstypes.Nullable{Inner: &rstypes.Vec{Inner: inner}}},
+				},
+			},
+		}
+	}
+	if got := describeChanges(tags(&rstypes.Number{IsSigned: true}), tags(&rstypes.String{})); got != "changed Item" {
+		t.Errorf("describeChanges() = %q, want a changed slice element", got)
+	}
+
+	shape := func(circle rstypes.Type) map[string]rstypes.Type {
+		return map[string]rstypes.Type{
+			"example.com/api.Shape": &rstypes.Enum{
+				Name:     "example.com/api.Shape",
+				Variants: map[string]rstypes.EnumVariant{"Circle": {Type: circle}},
+			},
+		}
+	}
+	if got := describeChanges(shape(&rstypes.Number{IsFloat: true}), shape(&rstypes.Struct{Name: "example.com/api.Circle"})); got != "changed Shape" {
+		t.Errorf("describeChanges() = %q, want a changed enum variant", got)
+	}
+
+	service := func(returns rstypes.Type) map[string]rstypes.Type {
+		return map[string]rstypes.Type{
+			"example.com/api.Service": &rstypes.Trait{
+				Name:    "example.com/api.Service",
+				Methods: []*rstypes.Function{{Name: "Get", Returns: returns}},
+			},
+		}
+	}
+	if got := describeChanges(service(&rstypes.Primitive{Name: "uuid::Uuid"}), service(&rstypes.Primitive{Name: "String"})); got != "changed Service" {
+		t.Errorf("describeChanges() = %q, want a changed method", got)
+	}
+
+	if got := describeChanges(cur, cur); got != "no type changes" {
+		t.Errorf("describeChanges() = %q, want no changes", got)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.go")
+
+	if err := os.WriteFile(path, []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.rs"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := snapshot(dir)
+	if err != nil {
+		t.Fatalf("snapshot() failed: %+v", err)
+	}
+	if _, ok := before[path]; !ok || len(before) != 1 {
+		t.Fatalf("snapshot() = %v, want only %s", before, path)
+	}
+
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := snapshot(dir)
+	if err != nil {
+		t.Fatalf("snapshot() failed: %+v", err)
+	}
+	if sameStamps(before, after) {
+		t.Errorf("sameStamps() did not notice the change")
+	}
+}
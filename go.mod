@@ -1,18 +1,15 @@
 module github.com/drewstone/go2rs
 
-go 1.22
-
-toolchain go1.22.8
+go 1.25.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/go-generalize/go-easyparser v0.4.1
 	github.com/google/go-cmp v0.6.0
-	golang.org/x/mod v0.19.0
+	github.com/pmezard/go-difflib v1.0.0
+	golang.org/x/mod v0.35.0
+	golang.org/x/tools v0.44.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/go-utils/gopackages v0.1.0 // indirect
-	golang.org/x/sync v0.7.0 // indirect
-	golang.org/x/tools v0.23.0 // indirect
-	golang.org/x/xerrors v0.0.0-20240716161551-93cc26a95ae9 // indirect
-)
+require golang.org/x/sync v0.20.0 // indirect
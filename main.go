@@ -2,7 +2,11 @@
 package go2rs
 
 import (
+	"reflect"
+
+	"github.com/drewstone/go2rs/pkg/config"
 	"github.com/drewstone/go2rs/pkg/generator"
+	"github.com/drewstone/go2rs/pkg/reflector"
 	rstypes "github.com/drewstone/go2rs/pkg/types"
 )
 
@@ -13,6 +17,23 @@ func NewGenerator(types map[string]rstypes.Type) *Generator {
 	return generator.NewGenerator(types)
 }
 
+// FromReflect converts a Go type into Rust types at runtime
+func FromReflect(t reflect.Type) (Type, error) {
+	return reflector.FromReflect(t)
+}
+
+// LoadConfig finds go2rs.yaml or go2rs.toml from dir up to the root directory,
+// loads it and applies its generation settings to g
+func LoadConfig(dir string, g *Generator) (*Config, error) {
+	cfg, err := config.LoadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Apply(g)
+
+	return cfg, nil
+}
+
 // Re-export all types
 type (
 	// Core types
@@ -38,15 +59,35 @@ type (
 	Struct    = rstypes.Struct
 	Trait     = rstypes.Trait
 	Tuple     = rstypes.Tuple
+	TypeParam = rstypes.TypeParam
 	Unit      = rstypes.Unit
 	Vec       = rstypes.Vec
 
+	// Type overrides
+	Override  = generator.Override
+	Overrides = generator.Overrides
+
+	// Project configuration
+	Config = config.Config
+
+	// Enum tagging
+	Tagging = rstypes.Tagging
+
 	// Field types
 	StructField   = rstypes.StructField
 	EnumVariant   = rstypes.EnumVariant
 	FunctionParam = rstypes.FunctionParam
 )
 
+// Enum tagging
+const (
+	TaggingDefault  = rstypes.TaggingDefault
+	TaggingExternal = rstypes.TaggingExternal
+	TaggingInternal = rstypes.TaggingInternal
+	TaggingAdjacent = rstypes.TaggingAdjacent
+	TaggingUntagged = rstypes.TaggingUntagged
+)
+
 // Constructor functions
 func NewAny() *Any                        { return &Any{} }
 func NewArray(inner Type) *Array          { return &Array{Inner: inner} }
@@ -67,7 +108,8 @@ func NewStruct(name string) *Struct {
 		Fields: make(map[string]StructField),
 	}
 }
-func NewTrait(name string) *Trait { return &Trait{Name: name} }
-func NewTuple() *Tuple            { return &Tuple{} }
-func NewUnit() *Unit              { return &Unit{} }
-func NewVec(inner Type) *Vec      { return &Vec{Inner: inner} }
+func NewTrait(name string) *Trait         { return &Trait{Name: name} }
+func NewTuple() *Tuple                    { return &Tuple{} }
+func NewTypeParam(name string) *TypeParam { return &TypeParam{Name: name, Constraint: "any"} }
+func NewUnit() *Unit                      { return &Unit{} }
+func NewVec(inner Type) *Vec              { return &Vec{Inner: inner} }
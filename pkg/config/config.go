@@ -0,0 +1,274 @@
+// Package config loads go2rs.yaml and go2rs.toml project configuration files
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/drewstone/go2rs/pkg/generator"
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the names of configuration files in the order they are searched for
+var FileNames = []string{"go2rs.yaml", "go2rs.yml", "go2rs.toml"}
+
+// taggings maps the values of enum_tagging onto the tagging of enums
+var taggings = map[string]rstypes.Tagging{
+	"external": rstypes.TaggingExternal,
+	"internal": rstypes.TaggingInternal,
+	"adjacent": rstypes.TaggingAdjacent,
+	"untagged": rstypes.TaggingUntagged,
+}
+
+// ErrNotFound is returned by Find when no configuration file exists
+var ErrNotFound = errors.New("config file not found")
+
+// Config is the configuration of go2rs for a project
+type Config struct {
+	// Packages are the directories of the Go packages to scan, relative to the configuration file
+	Packages []string `yaml:"packages" toml:"packages"`
+	// Base is the base package whose types keep their plain names (default: the first package)
+	Base string `yaml:"base" toml:"base"`
+	// All also generates unexported types and types outside the base package
+	All bool `yaml:"all" toml:"all"`
+	// Include and Exclude are glob patterns of the type names to generate
+	Include []string `yaml:"include" toml:"include"`
+	Exclude []string `yaml:"exclude" toml:"exclude"`
+
+	// Output is the file, or the directory with the files layout, relative to the configuration file
+	Output string `yaml:"output" toml:"output"`
+	// Layout is flat, modules or files
+	Layout string `yaml:"layout" toml:"layout"`
+	// ModuleRoot is the path of the root module in references across modules
+	ModuleRoot string `yaml:"module_root" toml:"module_root"`
+	// AltPackages maps packages onto alternative names
+	AltPackages map[string]string `yaml:"alt_packages" toml:"alt_packages"`
+
+	// Derives are derived by every generated type in addition to the default ones
+	Derives []string `yaml:"derives" toml:"derives"`
+	// FieldNaming is snake_case or preserve
+	FieldNaming string `yaml:"field_naming" toml:"field_naming"`
+	// IntType and UintType are the Rust types of Go's platform sized int and uint
+	IntType  string `yaml:"int_type" toml:"int_type"`
+	UintType string `yaml:"uint_type" toml:"uint_type"`
+	// EnumTagging is external, internal, adjacent or untagged
+	EnumTagging string `yaml:"enum_tagging" toml:"enum_tagging"`
+	// EnumTag and EnumContent are the field names of the tag and the content of enums
+	EnumTag     string `yaml:"enum_tag" toml:"enum_tag"`
+	EnumContent string `yaml:"enum_content" toml:"enum_content"`
+	// ErrorType is the Rust type of Go's error in the Result of trait methods
+	ErrorType string `yaml:"error_type" toml:"error_type"`
+	// AsyncTraits generates every trait method as an async fn
+	AsyncTraits bool `yaml:"async_traits" toml:"async_traits"`
+	// DynamicType is the path of the Rust type of Go's interface{} and any
+	DynamicType string `yaml:"dynamic_type" toml:"dynamic_type"`
+	// Overrides maps fully qualified Go type names onto Rust types
+	Overrides map[string]Override `yaml:"overrides" toml:"overrides"`
+
+	// Dir is the directory of the configuration file
+	Dir string `yaml:"-" toml:"-"`
+}
+
+// Override maps a Go named type onto an existing Rust type
+type Override struct {
+	RustType        string   `yaml:"rust_type" toml:"rust_type"`
+	Imports         []string `yaml:"imports" toml:"imports"`
+	SerdeWith       string   `yaml:"serde_with" toml:"serde_with"`
+	OptionSerdeWith string   `yaml:"option_serde_with" toml:"option_serde_with"`
+}
+
+// Find searches for a configuration file from dir up to the root directory
+func Find(dir string) (string, error) {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(base, name)
+
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+
+		parent := filepath.Dir(base)
+		if parent == base {
+			return "", ErrNotFound
+		}
+		base = parent
+	}
+}
+
+// Load reads the configuration file in path in YAML or TOML depending on its extension
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, cfg)
+	case ".toml":
+		err = toml.Unmarshal(b, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Dir = dir
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// LoadConfig finds the configuration file from dir and loads it
+func LoadConfig(dir string) (*Config, error) {
+	path, err := Find(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(path)
+}
+
+func (c *Config) validate() error {
+	switch c.Layout {
+	case "", "flat", "modules", "files":
+	default:
+		return fmt.Errorf("unknown layout: %s", c.Layout)
+	}
+
+	switch c.FieldNaming {
+	case "", "snake_case", "preserve":
+	default:
+		return fmt.Errorf("unknown field naming: %s", c.FieldNaming)
+	}
+
+	switch c.IntType {
+	case "", "i8", "i16", "i32", "i64", "i128", "isize":
+	default:
+		return fmt.Errorf("int_type must be a signed Rust integer: %s", c.IntType)
+	}
+
+	switch c.UintType {
+	case "", "u8", "u16", "u32", "u64", "u128", "usize":
+	default:
+		return fmt.Errorf("uint_type must be an unsigned Rust integer: %s", c.UintType)
+	}
+
+	if _, ok := taggings[c.EnumTagging]; !ok && c.EnumTagging != "" {
+		return fmt.Errorf("unknown enum tagging: %s", c.EnumTagging)
+	}
+
+	for goType, o := range c.Overrides {
+		if o.RustType == "" {
+			return fmt.Errorf("override for %s has no rust_type", goType)
+		}
+	}
+
+	return nil
+}
+
+// PackageDirs returns the directories of Packages
+func (c *Config) PackageDirs() []string {
+	dirs := make([]string, 0, len(c.Packages))
+	for _, p := range c.Packages {
+		dirs = append(dirs, c.resolve(p))
+	}
+
+	return dirs
+}
+
+// OutputPath returns the path of Output, or "" when it is unset
+func (c *Config) OutputPath() string {
+	if c.Output == "" {
+		return ""
+	}
+
+	return c.resolve(c.Output)
+}
+
+// resolve returns path relative to the directory of the configuration file
+func (c *Config) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(c.Dir, path)
+}
+
+// Apply applies the generation settings to g
+func (c *Config) Apply(g *generator.Generator) {
+	if c.Base != "" {
+		g.BasePackage = c.Base
+	}
+	for pkg, name := range c.AltPackages {
+		g.SetAltPackage(pkg, name)
+	}
+
+	g.Include = append(g.Include, c.Include...)
+	g.Exclude = append(g.Exclude, c.Exclude...)
+
+	if c.Layout == "modules" || c.Layout == "files" {
+		g.Layout = generator.LayoutModules
+	}
+	if c.ModuleRoot != "" {
+		g.ModuleRoot = c.ModuleRoot
+	}
+
+	g.Derives = append(g.Derives, c.Derives...)
+	if c.FieldNaming == "preserve" {
+		g.FieldNaming = generator.FieldNamingPreserve
+	}
+	if c.IntType != "" {
+		g.IntType = c.IntType
+	}
+	if c.UintType != "" {
+		g.UintType = c.UintType
+	}
+	if c.EnumTagging != "" {
+		g.EnumTagging = taggings[c.EnumTagging]
+	}
+	if c.EnumTag != "" {
+		g.EnumTag = c.EnumTag
+	}
+	if c.EnumContent != "" {
+		g.EnumContent = c.EnumContent
+	}
+	if c.ErrorType != "" {
+		g.ErrorType = c.ErrorType
+	}
+	if c.AsyncTraits {
+		g.AsyncTraits = true
+	}
+	if c.DynamicType != "" {
+		g.DynamicType = c.DynamicType
+	}
+
+	for goType, o := range c.Overrides {
+		g.AddOverride(goType, generator.Override{
+			RustType:        o.RustType,
+			Imports:         o.Imports,
+			SerdeWith:       o.SerdeWith,
+			OptionSerdeWith: o.OptionSerdeWith,
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drewstone/go2rs/pkg/generator"
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFind(t *testing.T) {
+	want, err := filepath.Abs("./testdata/yaml/go2rs.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Find("./testdata/yaml/nested")
+	if err != nil {
+		t.Fatalf("Find() failed: %+v", err)
+	}
+	if got != want {
+		t.Errorf("Find() = %s, want %s", got, want)
+	}
+
+	if _, err := Find(t.TempDir()); err != ErrNotFound {
+		t.Errorf("Find() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestLoad_YAML(t *testing.T) {
+	cfg, err := LoadConfig("./testdata/yaml/nested")
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %+v", err)
+	}
+
+	dir, _ := filepath.Abs("./testdata/yaml")
+
+	if diff := cmp.Diff([]string{filepath.Join(dir, "api")}, cfg.PackageDirs()); diff != "" {
+		t.Errorf("PackageDirs() differed: %s", diff)
+	}
+	if got, want := cfg.OutputPath(), filepath.Join(dir, "../rust/src/types.rs"); got != want {
+		t.Errorf("OutputPath() = %s, want %s", got, want)
+	}
+
+	g := generator.NewGenerator(nil)
+	cfg.Apply(g)
+
+	if g.BasePackage != "github.com/example/api" {
+		t.Errorf("BasePackage = %s", g.BasePackage)
+	}
+	if g.Layout != generator.LayoutModules || g.ModuleRoot != "crate::types" {
+		t.Errorf("Layout = %v, ModuleRoot = %s", g.Layout, g.ModuleRoot)
+	}
+	if g.FieldNaming != generator.FieldNamingPreserve {
+		t.Errorf("FieldNaming = %v", g.FieldNaming)
+	}
+	if g.EnumTagging != rstypes.TaggingInternal || g.EnumTag != "kind" {
+		t.Errorf("EnumTagging = %v, EnumTag = %s", g.EnumTagging, g.EnumTag)
+	}
+	if g.ErrorType != "anyhow::Error" || !g.AsyncTraits {
+		t.Errorf("ErrorType = %s, AsyncTraits = %v", g.ErrorType, g.AsyncTraits)
+	}
+	if g.DynamicType != "rmpv::Value" {
+		t.Errorf("DynamicType = %s", g.DynamicType)
+	}
+	if diff := cmp.Diff([]string{"Eq", "Hash"}, g.Derives); diff != "" {
+		t.Errorf("Derives differed: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"Param*"}, g.Include); diff != "" {
+		t.Errorf("Include differed: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"*Internal"}, g.Exclude); diff != "" {
+		t.Errorf("Exclude differed: %s", diff)
+	}
+	if diff := cmp.Diff(generator.Overrides{
+		"github.com/google/uuid.UUID": {RustType: "Uuid", Imports: []string{"uuid::Uuid"}},
+	}, g.Overrides); diff != "" {
+		t.Errorf("Overrides differed: %s", diff)
+	}
+}
+
+func TestLoad_TOML(t *testing.T) {
+	cfg, err := Load("./testdata/toml/go2rs.toml")
+	if err != nil {
+		t.Fatalf("Load() failed: %+v", err)
+	}
+
+	g := generator.NewGenerator(nil)
+	cfg.Apply(g)
+
+	if g.Layout != generator.LayoutModules {
+		t.Errorf("Layout = %v", g.Layout)
+	}
+	if g.IntType != "isize" || g.UintType != "usize" {
+		t.Errorf("IntType = %s, UintType = %s", g.IntType, g.UintType)
+	}
+	if diff := cmp.Diff(generator.Overrides{
+		"github.com/shopspring/decimal.Decimal": {
+			RustType:        "Decimal",
+			Imports:         []string{"rust_decimal::Decimal"},
+			SerdeWith:       "rust_decimal::serde::str",
+			OptionSerdeWith: "rust_decimal::serde::str_option",
+		},
+	}, g.Overrides); diff != "" {
+		t.Errorf("Overrides differed: %s", diff)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go2rs.yaml")
+	if err := os.WriteFile(path, []byte("field_naming: kebab\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Errorf("expected an error for an unknown field naming")
+	}
+}
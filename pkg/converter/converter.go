@@ -0,0 +1,151 @@
+// Package converter converts types parsed by go-easyparser into rstypes
+package converter
+
+import (
+	"fmt"
+	"go/types"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	tstypes "github.com/go-generalize/go-easyparser/types"
+)
+
+// Convert converts the types parsed by go-easyparser into rstypes.
+// Enum candidates keep the order go-easyparser sorted them in.
+// Nodes shared in the parsed tree, including recursive references, stay shared in the result.
+func Convert(parsed map[string]tstypes.Type) (res map[string]rstypes.Type, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			var ok bool
+			err, ok = e.(error)
+
+			if !ok {
+				err = fmt.Errorf("%+v", e)
+			}
+		}
+	}()
+
+	c := &converter{
+		converted: make(map[tstypes.Type]rstypes.Type),
+	}
+
+	res = make(map[string]rstypes.Type, len(parsed))
+	for name, t := range parsed {
+		res[name] = c.convert(t)
+	}
+
+	return res, nil
+}
+
+type converter struct {
+	converted map[tstypes.Type]rstypes.Type
+}
+
+func (c *converter) convert(t tstypes.Type) rstypes.Type {
+	if converted, ok := c.converted[t]; ok {
+		return converted
+	}
+
+	var typ rstypes.Type
+	switch v := t.(type) {
+	case *tstypes.Object:
+		typ = c.convertObject(v)
+	case *tstypes.String:
+		typ = c.convertString(v)
+	case *tstypes.Number:
+		typ = c.convertNumber(v)
+	case *tstypes.Boolean:
+		typ = &rstypes.Boolean{}
+	case *tstypes.Date:
+		typ = &rstypes.Date{}
+	case *tstypes.Any:
+		typ = &rstypes.Any{}
+	case *tstypes.Array:
+		typ = &rstypes.Vec{Inner: c.convert(v.Inner)}
+	case *tstypes.Nullable:
+		typ = &rstypes.Nullable{Inner: c.convert(v.Inner)}
+	case *tstypes.Map:
+		typ = &rstypes.Map{Key: c.convert(v.Key), Value: c.convert(v.Value)}
+	default:
+		panic(fmt.Sprintf("unsupported type: %T", t))
+	}
+
+	c.copyCommon(typ, t)
+	c.converted[t] = typ
+
+	return typ
+}
+
+func (c *converter) copyCommon(dst rstypes.Type, src tstypes.Type) {
+	dst.SetPackageName(src.GetPackageName())
+	dst.SetPosition(src.GetPosition())
+
+	// go-easyparser only keeps the names of objects and enums
+	switch v := src.(type) {
+	case *tstypes.Object:
+		dst.SetGoType(v.Name)
+	case *tstypes.String:
+		dst.SetGoType(v.Name)
+	case *tstypes.Number:
+		dst.SetGoType(v.Name)
+	}
+}
+
+func (c *converter) convertObject(obj *tstypes.Object) rstypes.Type {
+	strct := &rstypes.Struct{
+		Name:   obj.Name,
+		Fields: make(map[string]rstypes.StructField, len(obj.Entries)),
+	}
+
+	// Registered before the fields are converted for recursive references
+	c.copyCommon(strct, obj)
+	c.converted[obj] = strct
+
+	for key, entry := range obj.Entries {
+		strct.Fields[key] = rstypes.StructField{
+			RawName:    entry.RawName,
+			RawTag:     entry.RawTag,
+			FieldIndex: entry.FieldIndex,
+			Type:       c.convert(entry.Type),
+			Position:   entry.Position,
+			Optional:   entry.Optional,
+		}
+	}
+
+	return strct
+}
+
+func (c *converter) convertString(str *tstypes.String) rstypes.Type {
+	typ := &rstypes.String{
+		Name: str.Name,
+		Enum: append([]string(nil), str.Enum...),
+	}
+
+	for _, e := range str.RawEnum {
+		typ.RawEnum = append(typ.RawEnum, rstypes.RawStringEnumCandidate{
+			Key:   e.Key,
+			Value: e.Value,
+		})
+	}
+
+	return typ
+}
+
+func (c *converter) convertNumber(num *tstypes.Number) rstypes.Type {
+	typ := &rstypes.Number{
+		Name: num.Name,
+		Enum: append([]int64(nil), num.Enum...),
+	}
+
+	if num.RawType != types.Invalid {
+		typ.SetRawType(num.RawType)
+	}
+
+	for _, e := range num.RawEnum {
+		typ.RawEnum = append(typ.RawEnum, rstypes.RawNumberEnumCandidate{
+			Key:   e.Key,
+			Value: e.Value,
+		})
+	}
+
+	return typ
+}
@@ -0,0 +1,189 @@
+package converter
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	tstypes "github.com/go-generalize/go-easyparser/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvert(t *testing.T) {
+	pos := &token.Position{Filename: "main.go", Line: 10, Column: 6}
+	fieldPos := &token.Position{Filename: "main.go", Line: 11, Column: 2}
+
+	status := &tstypes.String{
+		Common: tstypes.Common{PkgName: "main", Position: pos},
+		Name:   "example.com/main.Status",
+		Enum:   []string{"Failure", "OK"},
+		RawEnum: []tstypes.RawStringEnumCandidate{
+			{Key: "StatusFailure", Value: "Failure"},
+			{Key: "StatusOK", Value: "OK"},
+		},
+	}
+	parsed := map[string]tstypes.Type{
+		"example.com/main.Status": status,
+		"example.com/main.Param": &tstypes.Object{
+			Common: tstypes.Common{PkgName: "main", Position: pos},
+			Name:   "example.com/main.Param",
+			Entries: map[string]tstypes.ObjectEntry{
+				"status": {
+					RawName:    "Status",
+					RawTag:     `json:"status"`,
+					FieldIndex: 0,
+					Type:       status,
+					Position:   fieldPos,
+				},
+				"Version": {
+					RawName:    "Version",
+					FieldIndex: 1,
+					Type: &tstypes.Number{
+						RawType: types.Int,
+						Enum:    []int64{1, 2},
+						RawEnum: []tstypes.RawNumberEnumCandidate{
+							{Key: "V1", Value: int64(1)},
+							{Key: "V2", Value: int64(2)},
+						},
+					},
+				},
+				"Tags": {
+					RawName:    "Tags",
+					FieldIndex: 2,
+					Optional:   true,
+					Type: &tstypes.Nullable{
+						Inner: &tstypes.Array{Inner: &tstypes.String{}},
+					},
+				},
+				"Meta": {
+					RawName:    "Meta",
+					FieldIndex: 3,
+					Type: &tstypes.Map{
+						Key:   &tstypes.String{},
+						Value: &tstypes.Any{},
+					},
+				},
+				"Enabled": {
+					RawName:    "Enabled",
+					FieldIndex: 4,
+					Type:       &tstypes.Boolean{},
+				},
+				"CreatedAt": {
+					RawName:    "CreatedAt",
+					FieldIndex: 5,
+					Type:       &tstypes.Date{},
+				},
+			},
+		},
+	}
+
+	wantStatus := &rstypes.String{
+		Common: rstypes.Common{PkgName: "main", Position: pos, GoType: "example.com/main.Status"},
+		Name:   "example.com/main.Status",
+		Enum:   []string{"Failure", "OK"},
+		RawEnum: []rstypes.RawStringEnumCandidate{
+			{Key: "StatusFailure", Value: "Failure"},
+			{Key: "StatusOK", Value: "OK"},
+		},
+	}
+	want := map[string]rstypes.Type{
+		"example.com/main.Status": wantStatus,
+		"example.com/main.Param": &rstypes.Struct{
+			Common: rstypes.Common{PkgName: "main", Position: pos, GoType: "example.com/main.Param"},
+			Name:   "example.com/main.Param",
+			Fields: map[string]rstypes.StructField{
+				"status": {
+					RawName:    "Status",
+					RawTag:     `json:"status"`,
+					FieldIndex: 0,
+					Type:       wantStatus,
+					Position:   fieldPos,
+				},
+				"Version": {
+					RawName:    "Version",
+					FieldIndex: 1,
+					Type: &rstypes.Number{
+						RawType:   types.Int,
+						IsSigned:  true,
+						IsUnsized: true,
+						BitSize:   64,
+						Enum:      []int64{1, 2},
+						RawEnum: []rstypes.RawNumberEnumCandidate{
+							{Key: "V1", Value: int64(1)},
+							{Key: "V2", Value: int64(2)},
+						},
+					},
+				},
+				"Tags": {
+					RawName:    "Tags",
+					FieldIndex: 2,
+					Optional:   true,
+					Type: &rstypes.Nullable{
+						Inner: &rstypes.Vec{Inner: &rstypes.String{}},
+					},
+				},
+				"Meta": {
+					RawName:    "Meta",
+					FieldIndex: 3,
+					Type: &rstypes.Map{
+						Key:   &rstypes.String{},
+						Value: &rstypes.Any{},
+					},
+				},
+				"Enabled": {
+					RawName:    "Enabled",
+					FieldIndex: 4,
+					Type:       &rstypes.Boolean{},
+				},
+				"CreatedAt": {
+					RawName:    "CreatedAt",
+					FieldIndex: 5,
+					Type:       &rstypes.Date{},
+				},
+			},
+		},
+	}
+
+	got, err := Convert(parsed)
+	if err != nil {
+		t.Fatalf("Convert() failed: %+v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Convert() differed: %s", diff)
+	}
+
+	param := got["example.com/main.Param"].(*rstypes.Struct)
+	if param.Fields["status"].Type != got["example.com/main.Status"] {
+		t.Errorf("shared node was not preserved")
+	}
+}
+
+func TestConvert_Recursive(t *testing.T) {
+	recursive := &tstypes.Object{
+		Name:    "example.com/main.Recursive",
+		Entries: map[string]tstypes.ObjectEntry{},
+	}
+	recursive.Entries["Re"] = tstypes.ObjectEntry{
+		RawName: "Re",
+		Type:    &tstypes.Nullable{Inner: recursive},
+	}
+
+	got, err := Convert(map[string]tstypes.Type{
+		"example.com/main.Recursive": recursive,
+	})
+	if err != nil {
+		t.Fatalf("Convert() failed: %+v", err)
+	}
+
+	strct := got["example.com/main.Recursive"].(*rstypes.Struct)
+	nullable, ok := strct.Fields["Re"].Type.(*rstypes.Nullable)
+	if !ok {
+		t.Fatalf("expected *rstypes.Nullable, got %T", strct.Fields["Re"].Type)
+	}
+
+	if nullable.Inner != strct {
+		t.Errorf("recursive reference was not preserved")
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// maxSerdeArray is the largest size of arrays serde implements Serialize and Deserialize for
+const maxSerdeArray = 32
+
+// serdeAsImport is imported by modules with arrays larger than maxSerdeArray
+const serdeAsImport = "serde_with::serde_as"
+
+// serdeAs returns the type in the serde_as attribute of a field of type t, which is needed
+// when t contains arrays larger than maxSerdeArray, or "" otherwise.
+// Everything but the arrays is left to serde as _.
+func (g *Generator) serdeAs(t rstypes.Type, optional bool) string {
+	as, ok := g.serdeAsType(t)
+	if !ok {
+		return ""
+	}
+
+	if optional {
+		return fmt.Sprintf("Option<%s>", as)
+	}
+
+	return as
+}
+
+// serdeAsType returns the serde_as type of t and whether t contains arrays larger than maxSerdeArray
+func (g *Generator) serdeAsType(t rstypes.Type) (string, bool) {
+	if _, ok := g.lookupOverride(t); ok {
+		return "_", false
+	}
+
+	switch v := t.(type) {
+	case *rstypes.Array:
+		inner, ok := g.serdeAsType(v.Inner)
+		return fmt.Sprintf("[%s; %d]", inner, v.Size), ok || v.Size > maxSerdeArray
+
+	case *rstypes.Vec:
+		inner, ok := g.serdeAsType(v.Inner)
+		return fmt.Sprintf("Vec<%s>", inner), ok
+
+	case *rstypes.Nullable:
+		inner, ok := g.serdeAsType(v.Inner)
+		return fmt.Sprintf("Option<%s>", inner), ok
+
+	case *rstypes.Map:
+		key, keyOK := g.serdeAsType(v.Key)
+		value, valueOK := g.serdeAsType(v.Value)
+		return fmt.Sprintf("HashMap<%s, %s>", key, value), keyOK || valueOK
+
+	case *rstypes.Tuple:
+		// Tuple structs carry their own attributes
+		if v.Name != "" {
+			return "_", false
+		}
+
+		types := make([]string, 0, len(v.Types))
+		found := false
+		for _, elem := range v.Types {
+			as, ok := g.serdeAsType(elem)
+			types = append(types, as)
+			found = found || ok
+		}
+
+		if len(types) == 1 {
+			return fmt.Sprintf("(%s,)", types[0]), found
+		}
+		return "(" + strings.Join(types, ", ") + ")", found
+	}
+
+	return "_", false
+}
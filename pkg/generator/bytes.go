@@ -0,0 +1,145 @@
+package generator
+
+import (
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// base64Module is the name of the module generated into the root module with serde adapters for byte slices
+const base64Module = "go_base64"
+
+// base64Adapter serializes Vec<u8> like encoding/json does []byte, as a string in the standard padded base64 alphabet
+const base64Adapter = `/// Serializes Vec<u8> as a base64 string like Go's encoding/json
+pub mod go_base64 {
+	use base64::{engine::general_purpose::STANDARD, Engine as _};
+	use serde::{Deserialize, Deserializer, Serializer};
+
+	pub fn serialize<S: Serializer>(bytes: &[u8], serializer: S) -> Result<S::Ok, S::Error> {
+		serializer.serialize_str(&STANDARD.encode(bytes))
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Vec<u8>, D::Error> {
+		let s = String::deserialize(deserializer)?;
+		STANDARD.decode(s).map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<Vec<u8>> as a base64 string or null
+	pub mod option {
+		use base64::{engine::general_purpose::STANDARD, Engine as _};
+		use serde::{Deserialize, Deserializer, Serializer};
+
+		pub fn serialize<S: Serializer>(bytes: &Option<Vec<u8>>, serializer: S) -> Result<S::Ok, S::Error> {
+			match bytes {
+				Some(bytes) => serializer.serialize_str(&STANDARD.encode(bytes)),
+				None => serializer.serialize_none(),
+			}
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<Vec<u8>>, D::Error> {
+			let s = Option::<String>::deserialize(deserializer)?;
+			s.map(|s| STANDARD.decode(s).map_err(serde::de::Error::custom)).transpose()
+		}
+	}
+}`
+
+// isBytes reports whether t is a Go byte slice, which encoding/json encodes as a base64 string
+func isBytes(t rstypes.Type) bool {
+	v, ok := t.(*rstypes.Vec)
+	if !ok {
+		return false
+	}
+
+	n, ok := v.Inner.(*rstypes.Number)
+
+	return ok && !n.IsFloat && !n.IsSigned && !n.IsUnsized && n.BitSize == 8 && !isNumberEnum(n)
+}
+
+// bytesSerdeWith returns the path of the base64 adapter for a field of type t, or "" when t is not a byte slice
+func (g *Generator) bytesSerdeWith(t rstypes.Type, optional bool) string {
+	if _, ok := g.lookupOverride(t); ok {
+		return ""
+	}
+	if nullable, ok := t.(*rstypes.Nullable); ok {
+		t = nullable.Inner
+		optional = true
+	}
+
+	if _, ok := g.lookupOverride(t); ok || !isBytes(t) {
+		return ""
+	}
+
+	adapter := g.qualify("", base64Module)
+	if optional {
+		return adapter + "::option"
+	}
+
+	return adapter
+}
+
+// nestedAdapter returns a byte slice or an overridden type with a serde adapter in t which is inside other types,
+// where the adapter cannot be applied, or nil
+func (g *Generator) nestedAdapter(t rstypes.Type, nested bool) rstypes.Type {
+	if o, ok := g.lookupOverride(t); ok {
+		if nested && o.SerdeWith != "" {
+			return t
+		}
+		return nil
+	}
+
+	switch v := t.(type) {
+	case *rstypes.Vec:
+		if isBytes(v) {
+			if nested {
+				return v
+			}
+			return nil
+		}
+		return g.nestedAdapter(v.Inner, true)
+	case *rstypes.Array:
+		return g.nestedAdapter(v.Inner, true)
+	case *rstypes.Nullable:
+		return g.nestedAdapter(v.Inner, nested)
+	case *rstypes.Map:
+		if inner := g.nestedAdapter(v.Key, true); inner != nil {
+			return inner
+		}
+		return g.nestedAdapter(v.Value, true)
+	case *rstypes.Tuple:
+		if v.Name != "" {
+			return nil
+		}
+		for _, elem := range v.Types {
+			if inner := g.nestedAdapter(elem, true); inner != nil {
+				return inner
+			}
+		}
+	}
+
+	return nil
+}
+
+// adapterField returns the serde with path of a field of type t, reporting types inside other types
+// which the adapters of overridden types and byte slices cannot be applied to
+func (g *Generator) adapterField(t rstypes.Type, optional bool) string {
+	if inner := g.nestedAdapter(t, false); inner != nil {
+		if o, ok := g.lookupOverride(inner); ok {
+			g.report(SeverityWarning, t, "has a %s inside another type, which is not serialized with %s", goTypeName(inner), o.SerdeWith)
+		} else {
+			g.report(SeverityWarning, t, "has a []byte inside another type, which is serialized as an array of numbers instead of base64")
+		}
+	}
+
+	if with := g.fieldSerdeWith(t, optional); with != "" {
+		return with
+	}
+
+	return g.bytesSerdeWith(t, optional)
+}
+
+// base64Code returns the base64 adapter module when byte slices are generated
+func (g *Generator) base64Code() string {
+	if !g.usesBase64 {
+		return ""
+	}
+
+	return base64Adapter + "\n\n"
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// Severity is how serious a Diagnostic is
+type Severity int
+
+const (
+	// SeverityWarning is reported for mappings which lose information but still generate valid Rust
+	SeverityWarning Severity = iota
+	// SeverityError is reported when the generated Rust is incomplete or broken
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+
+	return "warning"
+}
+
+// Diagnostic is a problem found while generating Rust types
+type Diagnostic struct {
+	Severity Severity
+	Message  string
+	// GoType is the fully qualified name of the Go type the problem was found in
+	GoType string
+	// Position is where the problem is in the Go source if known
+	Position *token.Position
+}
+
+// String formats d like "models/order.go:42: field Meta has unsupported type chan int"
+func (d Diagnostic) String() string {
+	switch {
+	case d.Position != nil && d.Position.IsValid():
+		return fmt.Sprintf("%s:%d: %s", d.Position.Filename, d.Position.Line, d.Message)
+	case d.GoType != "":
+		return fmt.Sprintf("%s: %s", d.GoType, d.Message)
+	default:
+		return d.Message
+	}
+}
+
+// DiagnosticsError is returned by Generate when errors were reported
+type DiagnosticsError []Diagnostic
+
+func (e DiagnosticsError) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, d := range e {
+		lines = append(lines, d.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// fieldContext is the struct field or method being generated
+type fieldContext struct {
+	goType   string
+	name     string
+	position *token.Position
+	// kind is "field" or "method"
+	kind string
+}
+
+// Diagnostics returns the problems found by the last Generate or GenerateFiles
+func (g *Generator) Diagnostics() []Diagnostic {
+	return g.diagnostics
+}
+
+// err returns the DiagnosticsError with the errors reported since diagnostics were reset
+func (g *Generator) err() error {
+	errs := make(DiagnosticsError, 0)
+	for _, d := range g.diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// report adds a diagnostic about t, which is found in the field being generated if any
+func (g *Generator) report(severity Severity, t rstypes.Type, format string, args ...interface{}) {
+	d := Diagnostic{
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+
+	if f := g.field; f != nil {
+		kind := f.kind
+		if kind == "" {
+			kind = "field"
+		}
+
+		d.Message = fmt.Sprintf("%s %s %s", kind, f.name, d.Message)
+		d.GoType = f.goType
+		d.Position = f.position
+	}
+	if t != nil {
+		if d.GoType == "" {
+			d.GoType = goTypeName(t)
+		}
+		if d.Position == nil {
+			d.Position = t.GetPosition()
+		}
+	}
+
+	g.diagnostics = append(g.diagnostics, d)
+}
+
+// describe returns the Go type t was derived from for messages
+func describe(t rstypes.Type) string {
+	if name := goTypeName(t); name != "" {
+		return name
+	}
+
+	return strings.ToLower(strings.TrimPrefix(fmt.Sprintf("%T", t), "*rstypes."))
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// defaultDynamicType is the Rust type of Go's interface{} and any by default
+const defaultDynamicType = "serde_json::Value"
+
+// dynamicType returns the path of the Rust type of Go's interface{} and any
+func (g *Generator) dynamicType() string {
+	if g.DynamicType != "" {
+		return g.DynamicType
+	}
+
+	return defaultDynamicType
+}
+
+// dynamicImport returns the use declaration of the dynamic type, or "" when it is not a path
+func (g *Generator) dynamicImport() string {
+	if t := g.dynamicType(); strings.Contains(t, "::") {
+		return t
+	}
+
+	return ""
+}
+
+// dynamicName returns the name the dynamic type is referred to by, which is its full path
+// when it is not imported because a generated type has the same name
+func (g *Generator) dynamicName() string {
+	t := g.dynamicType()
+	if idx := strings.LastIndex(t, "::"); idx != -1 && g.uses[t] {
+		return t[idx+2:]
+	}
+
+	return t
+}
+
+// isDynamicObject reports whether m is a map[string]any, which is a serde_json::Map with the default dynamic type
+func (g *Generator) isDynamicObject(m *rstypes.Map) bool {
+	if g.dynamicType() != defaultDynamicType {
+		return false
+	}
+
+	key, ok := m.Key.(*rstypes.String)
+	if !ok || len(key.Enum) != 0 {
+		return false
+	}
+	_, ok = m.Value.(*rstypes.Any)
+
+	return ok
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// promotedField is a field of a struct or of the structs embedded in it, as encoding/json sees it
+type promotedField struct {
+	field rstypes.StructField
+	depth int
+	// tagged is set when the json tag names the field
+	tagged bool
+	// origin is the key of the embedded field of the outermost struct the field is promoted through, or "" for its own fields
+	origin string
+	// nullable is set when the field is promoted through an embedded pointer
+	nullable bool
+}
+
+// jsonName returns the name in the json key of the Go struct tag rawTag, or "" when it names none
+func jsonName(rawTag string) string {
+	name := strings.Split(reflect.StructTag(rawTag).Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// jsonTagged reports whether the json tag of f names the field
+func jsonTagged(f rstypes.StructField) bool {
+	return jsonName(f.RawTag) != ""
+}
+
+// embeddedStruct returns the struct embedded by f and whether it is embedded by pointer.
+// Overridden structs are opaque and never return ok.
+func (g *Generator) embeddedStruct(f rstypes.StructField) (s *rstypes.Struct, pointer, ok bool) {
+	if !f.Embedded {
+		return nil, false, false
+	}
+
+	t := f.Type
+	if nullable, isNullable := t.(*rstypes.Nullable); isNullable {
+		t = nullable.Inner
+		pointer = true
+	}
+	if _, overridden := g.lookupOverride(t); overridden {
+		return nil, false, false
+	}
+
+	s, ok = t.(*rstypes.Struct)
+	if !ok {
+		return nil, false, false
+	}
+
+	// References to named types are often other values with the same name
+	if s.Fields == nil && s.Name != "" {
+		if top, isStruct := g.types[s.Name].(*rstypes.Struct); isStruct {
+			s = top
+		}
+	}
+
+	return s, pointer, true
+}
+
+// visibleFields returns the fields encoding/json marshals for obj, keyed by their names.
+// Fields of embedded structs are promoted, and of the fields with the same name the shallowest one wins,
+// then the one named by a json tag. The others conflict and none of them is marshaled.
+func (g *Generator) visibleFields(obj *rstypes.Struct) map[string]promotedField {
+	candidates := make(map[string][]promotedField)
+	path := make(map[*rstypes.Struct]bool)
+
+	var walk func(s *rstypes.Struct, depth int, origin string, nullable bool)
+	walk = func(s *rstypes.Struct, depth int, origin string, nullable bool) {
+		// encoding/json stops at embedded structs already being promoted
+		if path[s] {
+			return
+		}
+		path[s] = true
+		defer delete(path, s)
+
+		for name, f := range s.Fields {
+			if inner, pointer, ok := g.embeddedStruct(f); ok {
+				innerOrigin := origin
+				if depth == 0 {
+					innerOrigin = name
+				}
+				walk(inner, depth+1, innerOrigin, nullable || pointer)
+				continue
+			}
+
+			candidates[name] = append(candidates[name], promotedField{
+				field:    f,
+				depth:    depth,
+				tagged:   jsonTagged(f),
+				origin:   origin,
+				nullable: nullable,
+			})
+		}
+	}
+	walk(obj, 0, "", false)
+
+	visible := make(map[string]promotedField, len(candidates))
+	for name, fields := range candidates {
+		sort.SliceStable(fields, func(i, j int) bool {
+			if fields[i].depth != fields[j].depth {
+				return fields[i].depth < fields[j].depth
+			}
+			return fields[i].tagged && !fields[j].tagged
+		})
+
+		if len(fields) > 1 && fields[0].depth == fields[1].depth && fields[0].tagged == fields[1].tagged {
+			continue
+		}
+		visible[name] = fields[0]
+	}
+
+	return visible
+}
+
+// structFields returns the fields obj is generated with.
+// Named embedded structs are flattened with #[serde(flatten)] when all their fields are promoted,
+// and otherwise the promoted fields which are not shadowed are inlined.
+func (g *Generator) structFields(obj *rstypes.Struct) map[string]rstypes.StructField {
+	embeds := false
+	for _, f := range obj.Fields {
+		embeds = embeds || f.Embedded
+	}
+	if !embeds {
+		return obj.Fields
+	}
+
+	visible := g.visibleFields(obj)
+
+	fields := make(map[string]rstypes.StructField, len(visible))
+	flattened := make(map[string]bool)
+	for name, f := range obj.Fields {
+		if inner, _, ok := g.embeddedStruct(f); ok && g.flattenable(name, inner, visible) {
+			fields[name] = f
+			flattened[name] = true
+		}
+	}
+
+	for name, p := range visible {
+		if flattened[p.origin] {
+			continue
+		}
+
+		// encoding/json omits the fields of nil embedded pointers
+		f := p.field
+		if p.nullable && !f.Optional && !f.Embedded {
+			f.Optional = true
+			if nullable, ok := f.Type.(*rstypes.Nullable); ok {
+				f.Type = nullable.Inner
+			}
+		}
+		fields[name] = f
+	}
+
+	return fields
+}
+
+// flattenable reports whether the struct embedded as the field name can be flattened,
+// which is when it is generated on its own and none of its fields are shadowed
+func (g *Generator) flattenable(name string, s *rstypes.Struct, visible map[string]promotedField) bool {
+	if s.Name == "" {
+		return false
+	}
+
+	for field := range g.visibleFields(s) {
+		if p, ok := visible[field]; !ok || p.origin != name {
+			return false
+		}
+	}
+
+	return true
+}
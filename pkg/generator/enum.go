@@ -0,0 +1,335 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// isNumberEnum reports whether n is a named integer type with constants, which is generated as a Rust enum
+func isNumberEnum(n *rstypes.Number) bool {
+	return n.Name != "" && len(n.RawEnum) > 0 && !n.IsFloat
+}
+
+// generateNumberEnum generates a fieldless enum with explicit discriminants serialized as numbers by serde_repr
+func (g *Generator) generateNumberEnum(num *rstypes.Number) string {
+	buf := bytes.NewBuffer(nil)
+
+	var name string
+	g.currentModule, name = splitKey(g.enumKey(num.Name))
+
+	goName := num.Name
+	if idx := strings.LastIndex(goName, "."); idx != -1 {
+		goName = goName[idx+1:]
+	}
+
+	values := make([]int64, 0, len(num.RawEnum))
+	for _, c := range num.RawEnum {
+		values = append(values, enumValue(c.Value))
+	}
+
+	buf.WriteString(g.derive("Debug", "Clone", "Copy", "PartialEq", "Serialize_repr", "Deserialize_repr"))
+	buf.WriteString(fmt.Sprintf("#[repr(%s)]\n", reprType(num, values)))
+	buf.WriteString(fmt.Sprintf("pub enum %s {\n", name))
+
+	seen := make(map[int64]string)
+	names := make(map[string]bool)
+	for i, c := range num.RawEnum {
+		// Rust rejects duplicate discriminants, so aliases of earlier constants are dropped
+		if prev, ok := seen[values[i]]; ok {
+			g.report(SeverityWarning, num, "constant %s has the same value as %s and is not generated", c.Key, prev)
+			continue
+		}
+		seen[values[i]] = c.Key
+
+		buf.WriteString(fmt.Sprintf("\t%s = %s,\n", uniqueName(names, variantName(c.Key, goName)), formatValue(c.Value)))
+	}
+
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// variantName strips the name of the enum type from the constant key, e.g. PriorityLow becomes Low
+func variantName(key, typeName string) string {
+	trimmed := strings.TrimPrefix(key, typeName)
+	if trimmed == "" || trimmed == key {
+		return key
+	}
+
+	// The rest must still be an identifier
+	r := []rune(trimmed)[0]
+	if !unicode.IsLetter(r) {
+		return key
+	}
+
+	return string(unicode.ToUpper(r)) + trimmed[len(string(r)):]
+}
+
+// uniqueName returns name, suffixed with a number when it is already in names, and adds it to names
+func uniqueName(names map[string]bool, name string) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	names[unique] = true
+
+	return unique
+}
+
+// reprType returns the integer type used as the representation of an enum with values.
+// Sized Go types keep their size, while platform sized ones use the smallest of 32 and 64 bits that fits.
+func reprType(num *rstypes.Number, values []int64) string {
+	if num.BitSize != 0 && !num.IsUnsized {
+		return num.String()
+	}
+
+	signed := num.IsSigned || num.BitSize == 0
+	for _, v := range values {
+		if signed && (v < math.MinInt32 || v > math.MaxInt32) {
+			return "i64"
+		}
+		if !signed && (v < 0 || v > math.MaxUint32) {
+			return "u64"
+		}
+	}
+
+	if signed {
+		return "i32"
+	}
+
+	return "u32"
+}
+
+// enumValue returns the value of a constant as int64 for comparisons
+func enumValue(v interface{}) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+
+	return 0
+}
+
+// formatValue formats the value of a constant as a Rust discriminant
+func formatValue(v interface{}) string {
+	if u, ok := v.(uint64); ok {
+		return fmt.Sprintf("%d", u)
+	}
+
+	return fmt.Sprintf("%d", enumValue(v))
+}
+
+// stringVariant is a variant of an enum generated from a string type
+type stringVariant struct {
+	name  string
+	value string
+}
+
+// stringVariants returns the variants of str.
+// Names come from the Go constants without the type name prefix, or from the values when the constants are unknown.
+func (g *Generator) stringVariants(str *rstypes.String) []stringVariant {
+	goName := str.Name
+	if idx := strings.LastIndex(goName, "."); idx != -1 {
+		goName = goName[idx+1:]
+	}
+
+	candidates := str.RawEnum
+	if len(candidates) == 0 {
+		for _, value := range str.Enum {
+			candidates = append(candidates, rstypes.RawStringEnumCandidate{Value: value})
+		}
+	}
+
+	variants := make([]stringVariant, 0, len(candidates))
+	names := make(map[string]bool)
+	values := make(map[string]string)
+	for _, c := range candidates {
+		// Both variants would deserialize from the same value
+		if prev, ok := values[c.Value]; ok {
+			g.report(SeverityWarning, str, "constant %s has the same value as %s and is not generated", c.Key, prev)
+			continue
+		}
+		values[c.Value] = c.Key
+
+		name := identifier(c.Value)
+		if c.Key != "" {
+			name = variantName(c.Key, goName)
+		}
+
+		variants = append(variants, stringVariant{name: uniqueName(names, name), value: c.Value})
+	}
+
+	return variants
+}
+
+// identifier converts an enum value like "in-progress" into a PascalCase identifier like InProgress
+func identifier(value string) string {
+	var buf strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		buf.WriteRune(r)
+	}
+
+	name := buf.String()
+	switch {
+	case name == "":
+		return "Empty"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "V" + name
+	}
+
+	return name
+}
+
+// generateDataEnum generates an enum whose variants carry values, tagged as chosen by the enum or the generator
+func (g *Generator) generateDataEnum(enum *rstypes.Enum) string {
+	buf := bytes.NewBuffer(nil)
+
+	var name string
+	if enum.Name != "" {
+		name = g.enumKey(enum.Name)
+	} else {
+		for enumName, e := range g.nestedEnums {
+			if e == rstypes.Type(enum) {
+				name = enumName
+				break
+			}
+		}
+	}
+
+	if name == "" {
+		g.report(SeverityError, enum, "could not determine the name of the enum")
+		return ""
+	}
+	g.currentModule, name = splitKey(name)
+
+	tagging, tag, content := g.tagging(enum)
+
+	for _, variant := range enum.Variants {
+		if variant.Type != nil && g.serdeAs(variant.Type, false) != "" {
+			buf.WriteString("#[serde_as]\n")
+			break
+		}
+	}
+
+	buf.WriteString(g.derive("Debug", "Clone", "PartialEq", "Serialize", "Deserialize"))
+	switch tagging {
+	case rstypes.TaggingInternal:
+		buf.WriteString(fmt.Sprintf("#[serde(tag = %q)]\n", tag))
+	case rstypes.TaggingAdjacent:
+		buf.WriteString(fmt.Sprintf("#[serde(tag = %q, content = %q)]\n", tag, content))
+	case rstypes.TaggingUntagged:
+		buf.WriteString("#[serde(untagged)]\n")
+	}
+	buf.WriteString(fmt.Sprintf("pub enum %s {\n", name))
+
+	variants := make([]string, 0, len(enum.Variants))
+	for k := range enum.Variants {
+		variants = append(variants, k)
+	}
+	sort.Slice(variants, func(i, j int) bool {
+		a, b := enum.Variants[variants[i]], enum.Variants[variants[j]]
+		if a.FieldIndex != b.FieldIndex {
+			return a.FieldIndex < b.FieldIndex
+		}
+		return variants[i] < variants[j]
+	})
+
+	for _, k := range variants {
+		variant := enum.Variants[k]
+		variantName := identifier(k)
+
+		if tag := jsonName(variant.RawTag); tag != "" && tag != variantName {
+			buf.WriteString(fmt.Sprintf("\t#[serde(rename = %q)]\n", tag))
+		} else if variantName != k {
+			buf.WriteString(fmt.Sprintf("\t#[serde(rename = %q)]\n", k))
+		}
+
+		if variant.Type == nil {
+			buf.WriteString(fmt.Sprintf("\t%s,\n", variantName))
+			continue
+		}
+
+		g.field = &fieldContext{
+			goType:   goTypeName(enum),
+			name:     k,
+			position: variant.Position,
+		}
+		if tagging == rstypes.TaggingInternal && !isObject(variant.Type) {
+			g.report(SeverityWarning, variant.Type, "is not an object, so it cannot carry the tag %q of an internally tagged enum", tag)
+		}
+		variantType := g.GenerateTypeSimple(variant.Type, k)
+		if with := g.adapterField(variant.Type, false); with != "" {
+			variantType = fmt.Sprintf("#[serde(with = %q)] %s", with, variantType)
+		}
+		g.field = nil
+
+		if as := g.serdeAs(variant.Type, false); as != "" {
+			variantType = fmt.Sprintf("#[serde_as(as = %q)] %s", as, variantType)
+		}
+
+		buf.WriteString(fmt.Sprintf("\t%s(%s),\n", variantName, variantType))
+	}
+
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// tagging returns the tagging of enum and the names of its tag and content fields
+func (g *Generator) tagging(enum *rstypes.Enum) (tagging rstypes.Tagging, tag, content string) {
+	tagging = enum.Tagging
+	if tagging == rstypes.TaggingDefault {
+		tagging = g.EnumTagging
+	}
+	if tagging == rstypes.TaggingDefault {
+		tagging = rstypes.TaggingExternal
+	}
+
+	tag, content = enum.Tag, enum.Content
+	if tag == "" {
+		tag = g.EnumTag
+	}
+	if tag == "" {
+		tag = "type"
+	}
+	if content == "" {
+		content = g.EnumContent
+	}
+	if content == "" {
+		content = "content"
+	}
+
+	return tagging, tag, content
+}
+
+// isObject reports whether t is serialized as a JSON object
+func isObject(t rstypes.Type) bool {
+	switch v := t.(type) {
+	case *rstypes.Struct, *rstypes.Map:
+		return true
+	case *rstypes.Nullable:
+		return isObject(v.Inner)
+	}
+
+	return false
+}
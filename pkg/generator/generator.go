@@ -9,6 +9,7 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/drewstone/go2rs/pkg/reflector"
 	rstypes "github.com/drewstone/go2rs/pkg/types"
 	"github.com/drewstone/go2rs/pkg/util"
 )
@@ -17,14 +18,66 @@ import (
 type Generator struct {
 	types   map[string]rstypes.Type
 	altPkgs map[string]string
-	typeMap map[reflect.Type]rstypes.Type // New field for type mappings
+	typeMap map[reflect.Type]rstypes.Type // Rust types replacing Go types in Register
+
+	reflector *reflector.Reflector
 
 	BasePackage     string
 	CustomGenerator func(t rstypes.Type) (generated string, union bool)
+	// Overrides maps Go named types onto existing Rust types instead of generating them
+	Overrides Overrides
+	// Layout is how the generated types are organized into Rust modules
+	Layout Layout
+	// ModuleRoot is the path of the root module used in references across modules (default: crate)
+	ModuleRoot string
+	// Derives are derived by every generated type in addition to the default ones
+	Derives []string
+	// FieldNaming is how the Rust fields of structs are named
+	FieldNaming FieldNaming
+	// IntType and UintType are the Rust types of Go's platform sized int and uint (default: i64 and u64)
+	IntType  string
+	UintType string
+	// EnumTagging is the tagging of enums with TaggingDefault (default: TaggingExternal)
+	EnumTagging rstypes.Tagging
+	// EnumTag and EnumContent are the field names of the tag and the content (default: type and content)
+	EnumTag     string
+	EnumContent string
+	// ErrorType is the Rust type of Go's error in the Result of trait methods (default: Error)
+	ErrorType string
+	// AsyncTraits generates every trait method as an async fn
+	AsyncTraits bool
+	// DynamicType is the path of the Rust type of Go's interface{} and any (default: serde_json::Value)
+	DynamicType string
+	// Include and Exclude are glob patterns selecting the top-level types to generate,
+	// matched against the type name with and without its package.
+	// Types the selected ones depend on are always generated.
+	Include []string
+	Exclude []string
+
+	// currentModule is the module being generated
+	currentModule string
+	// node is the key of the type being generated
+	node string
+	// recursion is where recursive types are boxed
+	recursion recursion
+	// field is the struct field or method being generated, for diagnostics
+	field *fieldContext
+	// signature is set while the types in a method signature are generated
+	signature bool
+	// uses are the paths of the use declarations of the module being generated
+	uses map[string]bool
+
+	diagnostics []Diagnostic
+	// usesBase64 is set when byte slices need the base64 adapter
+	usesBase64 bool
+	// adapters are the modules of the wire type adapters which are needed
+	adapters map[string]bool
+	// noPartialEq are the keys of the generated types which cannot derive PartialEq
+	noPartialEq map[string]bool
 
 	// Track nested types that need to be generated
-	nestedTypes map[string]*rstypes.Struct
-	nestedEnums map[string]*rstypes.String
+	nestedTypes map[string]rstypes.Type // *rstypes.Struct, *rstypes.Tuple or *rstypes.Trait
+	nestedEnums map[string]rstypes.Type // *rstypes.String, *rstypes.Number or *rstypes.Enum
 }
 
 // Update NewGenerator
@@ -33,26 +86,160 @@ func NewGenerator(types map[string]rstypes.Type) *Generator {
 		types:       types,
 		altPkgs:     make(map[string]string),
 		typeMap:     make(map[reflect.Type]rstypes.Type),
-		nestedTypes: make(map[string]*rstypes.Struct),
-		nestedEnums: make(map[string]*rstypes.String),
+		nestedTypes: make(map[string]rstypes.Type),
+		nestedEnums: make(map[string]rstypes.Type),
+	}
+}
+
+// SetAltPackage sets an alternative name for the Go package pkg.
+// Types declared in pkg are prefixed with name instead of being disambiguated automatically.
+func (g *Generator) SetAltPackage(pkg, name string) {
+	if g.altPkgs == nil {
+		g.altPkgs = make(map[string]string)
 	}
+	g.altPkgs[pkg] = name
 }
 
-// Add new method
+// AddTypes adds Rust types which replace the Go types in typeMap when values are registered
 func (g *Generator) AddTypes(typeMap map[reflect.Type]rstypes.Type) {
+	if g.typeMap == nil {
+		g.typeMap = make(map[reflect.Type]rstypes.Type)
+	}
 	for t, rustType := range typeMap {
 		g.typeMap[t] = rustType
 	}
 }
 
-func (g *Generator) Generate() string {
-	buf := bytes.NewBuffer(nil)
+// Register converts the types of values with reflect and adds them to the generated types
+// along with the named types they depend on.
+// Values can also be reflect.Type. Pointers are dereferenced, and the types must be named.
+func (g *Generator) Register(values ...interface{}) error {
+	if g.types == nil {
+		g.types = make(map[string]rstypes.Type)
+	}
+	if g.reflector == nil {
+		g.reflector = reflector.NewReflector()
+	}
+	g.reflector.Overrides = g.typeMap
+
+	reachable := make(map[rstypes.Type]bool)
+	for _, v := range values {
+		t, ok := v.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(v)
+		}
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t == nil {
+			return fmt.Errorf("cannot register nil")
+		}
+
+		name := reflector.TypeName(t)
+		if name == "" {
+			return fmt.Errorf("cannot register unnamed type %s", t)
+		}
+
+		typ, err := g.reflector.FromReflect(t)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", name, err)
+		}
+		g.markReachable(reachable, typ, true)
+	}
+
+	// Types replaced by overrides and wire types are converted too, but their dependencies are not generated
+	for name, typ := range g.reflector.Types() {
+		if reachable[typ] {
+			g.types[name] = typ
+		}
+	}
+
+	return nil
+}
+
+// markReachable marks the types converted by reflect which are generated along with t
+func (g *Generator) markReachable(reachable map[rstypes.Type]bool, t rstypes.Type, root bool) {
+	if t == nil || reachable[t] {
+		return
+	}
+	if _, ok := g.lookupOverride(t); ok && !root {
+		return
+	}
+	reachable[t] = true
+
+	switch v := t.(type) {
+	case *rstypes.Struct:
+		for _, f := range v.Fields {
+			g.markReachable(reachable, f.Type, false)
+		}
+	case *rstypes.Nullable:
+		g.markReachable(reachable, v.Inner, false)
+	case *rstypes.Vec:
+		g.markReachable(reachable, v.Inner, false)
+	case *rstypes.Array:
+		g.markReachable(reachable, v.Inner, false)
+	case *rstypes.Map:
+		g.markReachable(reachable, v.Key, false)
+		g.markReachable(reachable, v.Value, false)
+	case *rstypes.Stream:
+		g.markReachable(reachable, v.Inner, false)
+	}
+}
+
+// Generate generates the Rust types.
+// In LayoutModules, every module is generated inline as nested pub mod blocks.
+// The problems found are available from Diagnostics, and a DiagnosticsError is returned
+// when the generated code is incomplete.
+func (g *Generator) Generate() (string, error) {
+	g.diagnostics = nil
 
 	// First collect all types, including nested ones
 	g.collectAllTypes()
 
+	if g.Layout == LayoutModules {
+		generated := g.generateModuleTree()
+		return generated, g.err()
+	}
+
+	enumNames := make([]string, 0)
+	for name := range g.nestedEnums {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+
+	structNames := make([]string, 0)
+	for name := range g.nestedTypes {
+		structNames = append(structNames, name)
+	}
+	sort.Strings(structNames)
+
+	generated := g.generateModule("", enumNames, structNames) + g.adapterCode()
+
+	return generated, g.err()
+}
+
+// generateModule generates the imports and the types with the keys in a module
+func (g *Generator) generateModule(module string, enumNames, structNames []string) string {
+	buf := bytes.NewBuffer(nil)
+	g.currentModule = module
+
+	roots := make([]rstypes.Type, 0, len(enumNames)+len(structNames))
+	names := make([]string, 0, len(enumNames)+len(structNames))
+	for _, name := range enumNames {
+		roots = append(roots, g.nestedEnums[name])
+		_, name = splitKey(name)
+		names = append(names, name)
+	}
+	for _, name := range structNames {
+		roots = append(roots, g.nestedTypes[name])
+		_, name = splitKey(name)
+		names = append(names, name)
+	}
+
 	// Add required imports based on type analysis
-	imports := g.determineRequiredImports()
+	imports := g.determineRequiredImports(roots, names)
+	g.uses = imports.paths
 	buf.WriteString("use serde::{Serialize, Deserialize};\n")
 	if imports.hasHashMap {
 		buf.WriteString("use std::collections::HashMap;\n")
@@ -60,31 +247,39 @@ func (g *Generator) Generate() string {
 	if imports.hasDateTime {
 		buf.WriteString("use chrono::{DateTime, Utc};\n")
 	}
+	for _, use := range imports.uses() {
+		buf.WriteString(fmt.Sprintf("use %s;\n", use))
+	}
 	buf.WriteString("\n")
 
 	// Generate enums first (both top-level and nested)
-	enumNames := make([]string, 0)
-	for name := range g.nestedEnums {
-		enumNames = append(enumNames, name)
-	}
-	sort.Strings(enumNames)
-
 	for _, name := range enumNames {
-		buf.WriteString(g.generateEnum(g.nestedEnums[name]))
+		g.node = name
+		switch v := g.nestedEnums[name].(type) {
+		case *rstypes.String:
+			buf.WriteString(g.generateEnum(v))
+		case *rstypes.Number:
+			buf.WriteString(g.generateNumberEnum(v))
+		case *rstypes.Enum:
+			buf.WriteString(g.generateDataEnum(v))
+		}
 		buf.WriteString("\n\n")
 	}
 
 	// Generate structs (both top-level and nested)
-	structNames := make([]string, 0)
-	for name := range g.nestedTypes {
-		structNames = append(structNames, name)
-	}
-	sort.Strings(structNames)
-
 	for _, name := range structNames {
-		buf.WriteString(g.generateStruct(g.nestedTypes[name]))
+		g.node = name
+		switch v := g.nestedTypes[name].(type) {
+		case *rstypes.Struct:
+			buf.WriteString(g.generateStruct(v))
+		case *rstypes.Tuple:
+			buf.WriteString(g.generateTupleStruct(v))
+		case *rstypes.Trait:
+			buf.WriteString(g.generateTrait(v))
+		}
 		buf.WriteString("\n\n")
 	}
+	g.node = ""
 
 	return buf.String()
 }
@@ -92,108 +287,277 @@ func (g *Generator) Generate() string {
 // collectAllTypes traverses the type hierarchy and collects all nested types
 func (g *Generator) collectAllTypes() {
 	if g.nestedTypes == nil {
-		g.nestedTypes = make(map[string]*rstypes.Struct)
+		g.nestedTypes = make(map[string]rstypes.Type)
 	}
 	if g.nestedEnums == nil {
-		g.nestedEnums = make(map[string]*rstypes.String)
+		g.nestedEnums = make(map[string]rstypes.Type)
 	}
 
+	g.usesBase64 = false
+	g.adapters = make(map[string]bool)
 	seen := make(map[rstypes.Type]bool)
 
-	var registerTypes func(t rstypes.Type, parentName string)
-	registerTypes = func(t rstypes.Type, parentName string) {
+	// Anonymous types are generated in the module of the named type they are found in
+	var registerTypes func(t rstypes.Type, parentName, module string)
+	registerTypes = func(t rstypes.Type, parentName, module string) {
 		if t == nil || seen[t] {
 			return
 		}
+		// Overridden types are never generated
+		if _, ok := g.lookupOverride(t); ok {
+			return
+		}
 		seen[t] = true
 		defer delete(seen, t)
 
 		switch v := t.(type) {
 		case *rstypes.Struct:
+			// Instances of generic structs are generated as their declarations
+			if len(v.TypeArgs) != 0 {
+				for i, arg := range v.TypeArgs {
+					registerTypes(arg, tupleElement(parentName, i), module)
+				}
+				if decl := g.genericDecl(v); decl != nil {
+					registerTypes(decl, "", module)
+				}
+				return
+			}
+
 			// For named types, always register them
 			if v.Name != "" {
-				typeName := g.getTypeNameFromFullPath(v.Name)
-				g.nestedTypes[typeName] = v
+				g.registerStruct(v.Name, v)
+				module = g.moduleOf(v.Name)
 			} else if parentName != "" {
-				g.nestedTypes[parentName] = v
+				g.nestedTypes[key(module, parentName)] = v
 			}
 
 			// Process fields
-			if v.Fields != nil {
-				for fieldName, entry := range v.Fields {
-					registerTypes(entry.Type, fieldName)
-				}
+			for fieldName, entry := range g.structFields(v) {
+				registerTypes(entry.Type, fieldName, module)
 			}
 		case *rstypes.String:
 			if len(v.Enum) > 0 && v.Name != "" {
-				_, name := util.SplitPackageStruct(v.Name)
-				g.nestedEnums[name] = v
+				g.nestedEnums[g.enumKey(v.Name)] = v
 			}
+		case *rstypes.Number:
+			if isNumberEnum(v) {
+				g.nestedEnums[g.enumKey(v.Name)] = v
+			}
+		case *rstypes.Enum:
+			if v.Name != "" {
+				g.registerEnum(v.Name, v)
+				module = g.moduleOf(v.Name)
+			} else if parentName != "" {
+				g.nestedEnums[key(module, parentName)] = v
+			}
+
+			for variantName, variant := range v.Variants {
+				registerTypes(variant.Type, variantName, module)
+			}
+		case *rstypes.Tuple:
+			// Anonymous tuples are written inline
+			if v.Name != "" {
+				g.registerStruct(v.Name, v)
+				module = g.moduleOf(v.Name)
+				parentName = g.tupleName(v)
+			}
+
+			for i, elem := range v.Types {
+				registerTypes(elem, tupleElement(parentName, i), module)
+			}
+		case *rstypes.Trait:
+			if v.IsError() {
+				return
+			}
+			g.registerStruct(v.Name, v)
 		}
 	}
 
 	// Phase 2: Process contents with cycle detection
-	var processContents func(t rstypes.Type, parentName string)
-	processContents = func(t rstypes.Type, parentName string) {
+	var processContents func(t rstypes.Type, parentName, module string)
+	processContents = func(t rstypes.Type, parentName, module string) {
 		if t == nil || seen[t] {
 			return
 		}
+		if _, ok := g.lookupOverride(t); ok {
+			g.useAdapter(t)
+			return
+		}
 		seen[t] = true
 		defer delete(seen, t)
 
 		switch v := t.(type) {
 		case *rstypes.Struct:
-			// For anonymous objects
-			if v.Name == "" && parentName != "" {
-				g.nestedTypes[parentName] = v
+			if len(v.TypeArgs) != 0 {
+				for i, arg := range v.TypeArgs {
+					registerTypes(arg, tupleElement(parentName, i), module)
+					processContents(arg, tupleElement(parentName, i), module)
+				}
+				if decl := g.genericDecl(v); decl != nil {
+					processContents(decl, "", module)
+				}
+				return
+			}
+
+			// Named types can also be reached only through other types
+			if v.Name != "" {
+				g.registerStruct(v.Name, v)
+				module = g.moduleOf(v.Name)
+			} else if parentName != "" {
+				g.nestedTypes[key(module, parentName)] = v
 			}
 
 			// Process fields
-			if v.Fields != nil {
-				for fieldName, entry := range v.Fields {
-					registerTypes(entry.Type, fieldName) // Register any nested named types
-					processContents(entry.Type, fieldName)
-				}
+			for fieldName, entry := range g.structFields(v) {
+				registerTypes(entry.Type, fieldName, module) // Register any nested named types
+				processContents(entry.Type, fieldName, module)
 			}
 
 		case *rstypes.String:
 			if len(v.Enum) > 0 && v.Name == "" && parentName != "" {
-				g.nestedEnums[parentName] = v
+				g.nestedEnums[key(module, parentName)] = v
 			}
 
+		case *rstypes.Number:
+			if isNumberEnum(v) {
+				g.nestedEnums[g.enumKey(v.Name)] = v
+			}
+
+		case *rstypes.Enum:
+			if v.Name != "" {
+				g.registerEnum(v.Name, v)
+				module = g.moduleOf(v.Name)
+			} else if parentName != "" {
+				g.nestedEnums[key(module, parentName)] = v
+			}
+
+			for variantName, variant := range v.Variants {
+				registerTypes(variant.Type, variantName, module)
+				processContents(variant.Type, variantName, module)
+			}
+
+		case *rstypes.Tuple:
+			if v.Name != "" {
+				g.registerStruct(v.Name, v)
+				module = g.moduleOf(v.Name)
+				parentName = g.tupleName(v)
+			}
+
+			for i, elem := range v.Types {
+				registerTypes(elem, tupleElement(parentName, i), module)
+				processContents(elem, tupleElement(parentName, i), module)
+			}
+
+		case *rstypes.Trait:
+			if v.IsError() {
+				return
+			}
+			g.registerStruct(v.Name, v)
+			module = g.moduleOf(v.Name)
+
+			for _, s := range v.Supertraits {
+				processContents(s, "", module)
+			}
+			for _, m := range v.Methods {
+				for _, p := range m.Params {
+					registerTypes(p.Type, m.Name+identifier(p.Name), module)
+					processContents(p.Type, m.Name+identifier(p.Name), module)
+				}
+				registerTypes(m.Returns, m.Name+"Result", module)
+				processContents(m.Returns, m.Name+"Result", module)
+			}
+
+		case *rstypes.Stream:
+			processContents(v.Inner, parentName, module)
+
+		case *rstypes.Result:
+			processContents(v.Ok, parentName, module)
+			processContents(v.Err, parentName, module)
+
+		case *rstypes.Function:
+			for _, p := range v.Params {
+				processContents(p.Type, parentName, module)
+			}
+			processContents(v.Returns, parentName, module)
+
 		case *rstypes.Array:
-			processContents(v.Inner, parentName)
+			processContents(v.Inner, parentName, module)
+
+		case *rstypes.Vec:
+			if isBytes(v) {
+				g.usesBase64 = true
+			}
+			processContents(v.Inner, parentName, module)
 
 		case *rstypes.Nullable:
-			processContents(v.Inner, parentName)
+			processContents(v.Inner, parentName, module)
 
 		case *rstypes.Map:
-			processContents(v.Key, parentName+"Key")
-			processContents(v.Value, parentName+"Value")
+			processContents(v.Key, parentName+"Key", module)
+			processContents(v.Value, parentName+"Value", module)
 		}
 	}
 
 	// Process all top-level types
-	for _, t := range g.types {
-		registerTypes(t, "")
+	for name, t := range g.types {
+		if g.selected(name) {
+			registerTypes(t, "", "")
+		}
+	}
+	for name, t := range g.types {
+		if g.selected(name) {
+			processContents(t, "", "")
+		}
+	}
+
+	g.analyzeRecursion()
+	g.analyzePartialEq()
+}
+
+// registerEnum registers a named enum unless it only refers to a top-level type
+func (g *Generator) registerEnum(name string, v rstypes.Type) {
+	if top, ok := g.types[name]; ok && top != v {
+		return
 	}
-	for _, t := range g.types {
-		processContents(t, "")
+
+	g.nestedEnums[g.enumKey(name)] = v
+}
+
+// registerStruct registers a named struct or tuple struct unless it only refers to a top-level type
+func (g *Generator) registerStruct(name string, v rstypes.Type) {
+	if top, ok := g.types[name]; ok && top != v {
+		return
 	}
+
+	g.nestedTypes[g.structKey(name)] = v
 }
 
 func (g *Generator) generateStruct(obj *rstypes.Struct) string {
 	buf := bytes.NewBuffer(nil)
 
-	buf.WriteString("#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]\n")
-	buf.WriteString("#[serde(rename_all = \"PascalCase\")]\n")
+	fieldMap := g.structFields(obj)
+
+	// serde_as must come before the derive
+	for _, entry := range fieldMap {
+		if g.serdeAs(entry.Type, entry.Optional) != "" {
+			buf.WriteString("#[serde_as]\n")
+			break
+		}
+	}
+
+	buf.WriteString(g.derive("Debug", "Clone", "PartialEq", "Serialize", "Deserialize"))
+	if g.FieldNaming == FieldNamingPreserve {
+		buf.WriteString("#[allow(non_snake_case)]\n")
+	} else {
+		buf.WriteString("#[serde(rename_all = \"PascalCase\")]\n")
+	}
 
 	var name string
 	if obj.Name != "" {
-		name = g.getTypeNameFromFullPath(obj.Name)
+		name = g.structKey(obj.Name)
 	} else {
 		for typeName, typ := range g.nestedTypes {
-			if typ == obj {
+			if typ == rstypes.Type(obj) {
 				name = typeName
 				break
 			}
@@ -201,14 +565,16 @@ func (g *Generator) generateStruct(obj *rstypes.Struct) string {
 	}
 
 	if name == "" {
-		panic("Could not determine struct name")
+		g.report(SeverityError, obj, "could not determine the name of the struct")
+		return ""
 	}
+	g.currentModule, name = splitKey(name)
 
-	buf.WriteString(fmt.Sprintf("pub struct %s {\n", name))
+	buf.WriteString(fmt.Sprintf("pub struct %s%s {\n", name, g.typeParams(obj)))
 
 	// Sort fields for consistent output
 	fields := make([]string, 0)
-	for k := range obj.Fields {
+	for k := range fieldMap {
 		fields = append(fields, k)
 	}
 	sort.Strings(fields)
@@ -229,11 +595,20 @@ func (g *Generator) generateStruct(obj *rstypes.Struct) string {
 
 	// Generate fields
 	for _, field := range fields {
-		entry := obj.Fields[field]
+		entry := fieldMap[field]
+
+		g.field = &fieldContext{
+			goType:   goTypeName(obj),
+			name:     field,
+			position: entry.Position,
+		}
 		fieldType := g.GenerateTypeSimple(entry.Type, field)
 
-		// Default to snake case
-		rustField := toSnakeCase(field)
+		// Fields of overridden types and byte slices may need a serde helper
+		serdeWith := g.adapterField(entry.Type, entry.Optional)
+		g.field = nil
+
+		rustField := g.fieldName(field)
 
 		// Check if this field needs to keep original casing due to collision
 		lower := strings.ToLower(field)
@@ -241,16 +616,33 @@ func (g *Generator) generateStruct(obj *rstypes.Struct) string {
 			rustField = field // Keep original casing
 		}
 
+		// Embedded structs are serialized in place of their fields
+		if entry.Embedded {
+			buf.WriteString("\t#[serde(flatten)]\n")
+			buf.WriteString(fmt.Sprintf("\tpub %s: %s,\n", rustField, fieldType))
+			continue
+		}
+
+		if as := g.serdeAs(entry.Type, entry.Optional); as != "" {
+			buf.WriteString(fmt.Sprintf("\t#[serde_as(as = %q)]\n", as))
+		}
+
 		if entry.Optional {
 			buf.WriteString("\t#[serde(skip_serializing_if = \"Option::is_none\")]\n")
-			if rustField != field {
+			if g.needsRename(rustField, field) {
 				buf.WriteString(fmt.Sprintf("\t#[serde(rename = \"%s\")]\n", field))
 			}
+			if serdeWith != "" {
+				buf.WriteString(fmt.Sprintf("\t#[serde(default, with = \"%s\")]\n", serdeWith))
+			}
 			buf.WriteString(fmt.Sprintf("\tpub %s: Option<%s>,\n", rustField, fieldType))
 		} else {
-			if rustField != field {
+			if g.needsRename(rustField, field) {
 				buf.WriteString(fmt.Sprintf("\t#[serde(rename = \"%s\")]\n", field))
 			}
+			if serdeWith != "" {
+				buf.WriteString(fmt.Sprintf("\t#[serde(with = \"%s\")]\n", serdeWith))
+			}
 			buf.WriteString(fmt.Sprintf("\tpub %s: %s,\n", rustField, fieldType))
 		}
 	}
@@ -264,11 +656,11 @@ func (g *Generator) generateEnum(str *rstypes.String) string {
 
 	var name string
 	if str.Name != "" {
-		_, name = util.SplitPackageStruct(str.Name)
+		name = g.enumKey(str.Name)
 	} else {
 		// Find the name from our nestedEnums map
 		for enumName, enum := range g.nestedEnums {
-			if enum == str {
+			if enum == rstypes.Type(str) {
 				name = enumName
 				break
 			}
@@ -276,31 +668,17 @@ func (g *Generator) generateEnum(str *rstypes.String) string {
 	}
 
 	if name == "" {
-		panic("Could not determine enum name")
-	}
-
-	buf.WriteString("#[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]\n")
-
-	// Special case for EnumArray values which should be lowercase
-	if name == "EnumArray" {
-		buf.WriteString("#[serde(rename_all = \"lowercase\")]\n")
-	} else {
-		buf.WriteString("#[serde(rename_all = \"PascalCase\")]\n")
-	}
-
-	// Add "Values" suffix when defining the enum
-	if name != "Status" {
-		name += "Values"
+		g.report(SeverityError, str, "could not determine the name of the enum")
+		return ""
 	}
+	g.currentModule, name = splitKey(name)
 
+	buf.WriteString(g.derive("Debug", "Clone", "Copy", "PartialEq", "Serialize", "Deserialize"))
 	buf.WriteString(fmt.Sprintf("pub enum %s {\n", name))
 
-	for _, variant := range str.Enum {
-		cleanVariant := strings.Trim(variant, "\"'")
-		if name == "EnumArrayValues" {
-			cleanVariant = strings.ToUpper(cleanVariant)
-		}
-		buf.WriteString(fmt.Sprintf("\t%s,\n", cleanVariant))
+	for _, v := range g.stringVariants(str) {
+		buf.WriteString(fmt.Sprintf("\t#[serde(rename = %q)]\n", v.value))
+		buf.WriteString(fmt.Sprintf("\t%s,\n", v.name))
 	}
 
 	buf.WriteString("}")
@@ -313,32 +691,85 @@ func (g *Generator) GenerateTypeSimple(t rstypes.Type, fieldName string) string
 }
 
 func (g *Generator) GenerateTypeSimpleWithContext(t rstypes.Type, fieldName string, typeStack []rstypes.Type) string {
+	if o, ok := g.lookupOverride(t); ok {
+		return o.RustType
+	}
+
 	switch v := t.(type) {
 	case *rstypes.Array:
 		inner := g.GenerateTypeSimpleWithContext(v.Inner, fieldName, typeStack)
+		return fmt.Sprintf("[%s; %d]", inner, v.Size)
+
+	case *rstypes.Vec:
+		inner := g.GenerateTypeSimpleWithContext(v.Inner, fieldName, append(typeStack, v))
 		return fmt.Sprintf("Vec<%s>", inner)
 
 	case *rstypes.Struct:
 		if v.Name == "" {
-			return fieldName
+			return g.boxed(v, fieldName, typeStack)
 		}
-		return g.getTypeNameFromFullPath(v.Name)
+		return g.boxed(v, g.qualify(splitKey(g.structKey(v.Name)))+g.typeArgs(v, fieldName, typeStack), typeStack)
+
+	case *rstypes.TypeParam:
+		return v.Name
 
 	case *rstypes.String:
 		if len(v.Enum) > 0 {
 			if v.Name != "" {
-				_, name := util.SplitPackageStruct(v.Name)
-				if name == "Status" {
-					return name
-				}
-				return name + "Values"
+				return g.qualify(splitKey(g.enumKey(v.Name)))
 			}
-			return fieldName + "Values"
+			return fieldName
 		}
 		return "String"
 
 	case *rstypes.Number:
-		return "u128"
+		if isNumberEnum(v) {
+			return g.qualify(splitKey(g.enumKey(v.Name)))
+		}
+		return g.numberType(v)
+
+	case *rstypes.Enum:
+		if v.Name == "" {
+			return g.boxed(v, fieldName, typeStack)
+		}
+		return g.boxed(v, g.qualify(splitKey(g.enumKey(v.Name))), typeStack)
+
+	case *rstypes.Tuple:
+		if v.Name == "" {
+			return g.tupleType(v, fieldName, typeStack)
+		}
+		return g.boxed(v, g.qualify(splitKey(g.structKey(v.Name))), typeStack)
+
+	case *rstypes.Trait:
+		if v.IsError() {
+			return g.errorType()
+		}
+		// encoding/json marshals interface values by their dynamic values
+		if !g.signature {
+			g.report(SeverityWarning, v, "has interface type %s, which is generated as %s", describe(v), g.dynamicName())
+			return g.dynamicName()
+		}
+		return fmt.Sprintf("Box<dyn %s>", g.traitRef(v))
+
+	case *rstypes.Result:
+		ok := "()"
+		if v.Ok != nil {
+			ok = g.GenerateTypeSimpleWithContext(v.Ok, fieldName, typeStack)
+		}
+		return fmt.Sprintf("Result<%s, %s>", ok, g.GenerateTypeSimpleWithContext(v.Err, fieldName, typeStack))
+
+	case *rstypes.Unit:
+		return "()"
+
+	case *rstypes.Stream:
+		return g.streamType(v, fieldName, typeStack)
+
+	case *rstypes.Function:
+		if !g.signature {
+			g.report(SeverityError, v, "has unsupported type %s", v.String())
+			return "Unknown"
+		}
+		return g.functionType(v, fieldName)
 
 	case *rstypes.Boolean:
 		return "bool"
@@ -347,23 +778,32 @@ func (g *Generator) GenerateTypeSimpleWithContext(t rstypes.Type, fieldName stri
 		return "DateTime<Utc>"
 
 	case *rstypes.Nullable:
-		// Check if the inner type is a recursive reference
-		if obj, ok := v.Inner.(*rstypes.Struct); ok && obj.Name != "" {
-			// Check if this object is in our known types
-			if knownType, exists := g.types[obj.Name]; exists && knownType == obj {
-				// This is a recursive reference to a top-level type
-				return fmt.Sprintf("Option<Box<%s>>", g.getTypeNameFromFullPath(obj.Name))
-			}
-		}
 		inner := g.GenerateTypeSimpleWithContext(v.Inner, fieldName, typeStack)
 		return fmt.Sprintf("Option<%s>", inner)
 
 	case *rstypes.Map:
-		key := g.GenerateTypeSimpleWithContext(v.Key, fieldName+"Key", typeStack)
-		value := g.GenerateTypeSimpleWithContext(v.Value, fieldName+"Value", typeStack)
+		switch v.Key.(type) {
+		case *rstypes.String, *rstypes.Number, *rstypes.Primitive, *rstypes.TypeParam:
+		default:
+			g.report(SeverityWarning, v, "has map key type %s, which cannot be a JSON object key", describe(v.Key))
+		}
+
+		if g.isDynamicObject(v) {
+			return fmt.Sprintf("serde_json::Map<String, %s>", g.dynamicName())
+		}
+
+		key := g.GenerateTypeSimpleWithContext(v.Key, fieldName+"Key", append(typeStack, v))
+		value := g.GenerateTypeSimpleWithContext(v.Value, fieldName+"Value", append(typeStack, v))
 		return fmt.Sprintf("HashMap<%s, %s>", key, value)
 
+	case *rstypes.Any:
+		return g.dynamicName()
+
+	case *rstypes.Primitive:
+		return g.primitiveType(v.Name)
+
 	default:
+		g.report(SeverityError, t, "has unsupported type %s", describe(t))
 		return "Unknown"
 	}
 }
@@ -383,12 +823,41 @@ func toSnakeCase(s string) string {
 type requiredImports struct {
 	hasHashMap  bool
 	hasDateTime bool
+
+	// paths for additional use declarations
+	paths map[string]bool
+
+	// named are the paths which are imported unless their names collide, with the first type each is found in
+	named map[string]rstypes.Type
+}
+
+// uses returns the additional use declarations in sorted order
+func (i requiredImports) uses() []string {
+	uses := make([]string, 0, len(i.paths))
+	for path := range i.paths {
+		uses = append(uses, path)
+	}
+	sort.Strings(uses)
+
+	return uses
 }
 
-func (g *Generator) determineRequiredImports() requiredImports {
-	imports := requiredImports{}
+// determineRequiredImports returns the imports needed by roots, which are the types generated in a module with names
+func (g *Generator) determineRequiredImports(roots []rstypes.Type, names []string) requiredImports {
+	imports := requiredImports{
+		paths: make(map[string]bool),
+		named: make(map[string]rstypes.Type),
+	}
 	seen := make(map[rstypes.Type]bool)
 
+	isRoot := make(map[rstypes.Type]bool, len(roots))
+	for _, t := range roots {
+		isRoot[t] = true
+	}
+
+	// signatures is the depth of the function signatures being checked
+	signatures := 0
+
 	var checkType func(t rstypes.Type)
 	checkType = func(t rstypes.Type) {
 		if t == nil || seen[t] {
@@ -397,29 +866,102 @@ func (g *Generator) determineRequiredImports() requiredImports {
 		seen[t] = true
 		defer delete(seen, t)
 
+		if o, ok := g.lookupOverride(t); ok {
+			for _, path := range o.Imports {
+				imports.paths[path] = true
+			}
+			return
+		}
+
 		switch v := t.(type) {
 		case *rstypes.Map:
-			imports.hasHashMap = true
+			if !g.isDynamicObject(v) {
+				imports.hasHashMap = true
+			}
 			checkType(v.Key)
 			checkType(v.Value)
+		case *rstypes.Any:
+			if path := g.dynamicImport(); path != "" {
+				imports.addNamed(path, v)
+			}
 		case *rstypes.Date:
 			imports.hasDateTime = true
+		case *rstypes.Primitive:
+			addPrimitiveImports(&imports, v)
 		case *rstypes.Array:
+			if v.Size > maxSerdeArray {
+				imports.paths[serdeAsImport] = true
+			}
+			checkType(v.Inner)
+		case *rstypes.Vec:
 			checkType(v.Inner)
 		case *rstypes.Nullable:
 			checkType(v.Inner)
+		case *rstypes.Number:
+			if isRoot[v] && isNumberEnum(v) {
+				imports.paths["serde_repr::{Serialize_repr, Deserialize_repr}"] = true
+			}
+		case *rstypes.Enum:
+			// Other enums are generated on their own
+			if !isRoot[v] {
+				return
+			}
+			for _, variant := range v.Variants {
+				checkType(variant.Type)
+			}
+		case *rstypes.Trait:
+			// Interfaces outside signatures hold dynamic values
+			if path := g.dynamicImport(); signatures == 0 && !v.IsError() && path != "" {
+				imports.addNamed(path, v)
+			}
+		case *rstypes.Function:
+			signatures++
+			for _, p := range v.Params {
+				checkType(p.Type)
+			}
+			checkType(v.Returns)
+			signatures--
+		case *rstypes.Result:
+			checkType(v.Ok)
+			checkType(v.Err)
+		case *rstypes.Stream:
+			if v.Direction == rstypes.StreamReceive {
+				imports.paths[streamImport] = true
+			}
+			checkType(v.Inner)
+		case *rstypes.Tuple:
+			// Named tuples are generated on their own
+			if v.Name != "" && !isRoot[v] {
+				return
+			}
+			for _, elem := range v.Types {
+				checkType(elem)
+			}
 		case *rstypes.Struct:
-			if v.Fields != nil {
-				for _, entry := range v.Fields {
-					checkType(entry.Type)
-				}
+			for _, arg := range v.TypeArgs {
+				checkType(arg)
+			}
+			// Other structs are generated on their own
+			if !isRoot[v] {
+				return
+			}
+			for _, entry := range g.structFields(v) {
+				checkType(entry.Type)
 			}
 		}
 	}
 
-	for _, t := range g.types {
+	for _, t := range roots {
+		// Traits referred to elsewhere are only references
+		if trait, ok := t.(*rstypes.Trait); ok {
+			for _, m := range trait.Methods {
+				checkType(m)
+			}
+			continue
+		}
 		checkType(t)
 	}
+	g.resolveNamedImports(&imports, names)
 
 	return imports
 }
@@ -438,6 +980,13 @@ func (g *Generator) getTypeNameFromFullPath(fullPath string) string {
 	// Get the base name (last part)
 	baseName := parts[len(parts)-1]
 
+	// Packages with an alternative name always use it as the prefix
+	if strings.Contains(fullPath, ".") {
+		if alt, ok := g.altPkgs[g.packageOf(fullPath)]; ok {
+			return alt + baseName
+		}
+	}
+
 	// Find all types with the same base name
 	duplicates := make([]string, 0)
 	for otherPath := range g.types {
@@ -451,8 +1000,14 @@ func (g *Generator) getTypeNameFromFullPath(fullPath string) string {
 		return baseName
 	}
 
-	// Sort duplicates for consistent ordering
-	sort.Strings(duplicates)
+	// Sort duplicates for consistent ordering, the base package always comes first
+	sort.Slice(duplicates, func(i, j int) bool {
+		bi, bj := g.packageOf(duplicates[i]) == g.BasePackage, g.packageOf(duplicates[j]) == g.BasePackage
+		if bi != bj {
+			return bi
+		}
+		return duplicates[i] < duplicates[j]
+	})
 
 	// Get parent directory/package name
 	parentName := ""
@@ -479,3 +1034,9 @@ func (g *Generator) getTypeNameFromFullPath(fullPath string) string {
 
 	return baseName
 }
+
+// packageOf returns the Go package path of a fully qualified type name
+func (g *Generator) packageOf(fullPath string) string {
+	pkg, _ := util.SplitPackageStruct(fullPath)
+	return pkg
+}
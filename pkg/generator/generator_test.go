@@ -2,7 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"go/token"
 	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/drewstone/go2rs/pkg/generator/testdata"
@@ -27,6 +31,8 @@ func TestGenerator_Generate(t *testing.T) {
 		altPkgs         map[string]string
 		BasePackage     string
 		CustomGenerator func(t rstypes.Type) (generated string, union bool)
+		Overrides       Overrides
+		Layout          Layout
 	}
 	tests := []struct {
 		name   string
@@ -91,6 +97,173 @@ func TestGenerator_Generate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "02_modules",
+			want: loadFile(t, "./testdata/02_modules.rs"),
+			fields: fields{
+				types:       testdata.Data02,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/conflict",
+				Layout:      LayoutModules,
+			},
+		},
+		{
+			name: "04_modules",
+			want: loadFile(t, "./testdata/04_modules.rs"),
+			fields: fields{
+				types:       testdata.Data04,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata",
+				Layout:      LayoutModules,
+			},
+		},
+		{
+			name: "06",
+			want: loadFile(t, "./testdata/06.rs"),
+			fields: fields{
+				types:       testdata.Data06,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/override",
+				Overrides: Overrides{
+					"github.com/google/uuid.UUID": {
+						RustType: "Uuid",
+						Imports:  []string{"uuid::Uuid"},
+					},
+					"github.com/shopspring/decimal.Decimal": {
+						RustType:        "Decimal",
+						Imports:         []string{"rust_decimal::Decimal"},
+						SerdeWith:       "rust_decimal::serde::str",
+						OptionSerdeWith: "rust_decimal::serde::str_option",
+					},
+				},
+			},
+		},
+		{
+			name: "07",
+			want: loadFile(t, "./testdata/07.rs"),
+			fields: fields{
+				types:       testdata.Data07,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/iota",
+			},
+		},
+		{
+			name: "08",
+			want: loadFile(t, "./testdata/08.rs"),
+			fields: fields{
+				types:       testdata.Data08,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/enum",
+			},
+		},
+		{
+			name: "09",
+			want: loadFile(t, "./testdata/09.rs"),
+			fields: fields{
+				types:       testdata.Data09,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/shape",
+			},
+		},
+		{
+			name: "10",
+			want: loadFile(t, "./testdata/10.rs"),
+			fields: fields{
+				types:       testdata.Data10,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/geo",
+			},
+		},
+		{
+			name: "11",
+			want: loadFile(t, "./testdata/11.rs"),
+			fields: fields{
+				types:       testdata.Data11,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/crypto",
+			},
+		},
+		{
+			name: "12",
+			want: loadFile(t, "./testdata/12.rs"),
+			fields: fields{
+				types:       testdata.Data12,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/blob",
+			},
+		},
+		{
+			name: "13",
+			want: loadFile(t, "./testdata/13.rs"),
+			fields: fields{
+				types:       testdata.Data13,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/service",
+			},
+		},
+		{
+			name: "14",
+			want: loadFile(t, "./testdata/14.rs"),
+			fields: fields{
+				types:       testdata.Data14,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/ext",
+			},
+		},
+		{
+			name: "15",
+			want: loadFile(t, "./testdata/15.rs"),
+			fields: fields{
+				types:       testdata.Data15,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/graph",
+			},
+		},
+		{
+			name: "16",
+			want: loadFile(t, "./testdata/16.rs"),
+			fields: fields{
+				types:       testdata.Data16,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/promote",
+			},
+		},
+		{
+			name: "17",
+			want: loadFile(t, "./testdata/17.rs"),
+			fields: fields{
+				types:       testdata.Data17,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/generics",
+			},
+		},
+		{
+			name: "18",
+			want: loadFile(t, "./testdata/18.rs"),
+			fields: fields{
+				types:       testdata.Data18,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/wire",
+			},
+		},
+		{
+			name: "19",
+			want: loadFile(t, "./testdata/19.rs"),
+			fields: fields{
+				types:       testdata.Data19,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/embed",
+			},
+		},
+		{
+			name: "20",
+			want: loadFile(t, "./testdata/20.rs"),
+			fields: fields{
+				types:       testdata.Data20,
+				altPkgs:     map[string]string{},
+				BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/naming",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,8 +272,13 @@ func TestGenerator_Generate(t *testing.T) {
 				BasePackage:     tt.fields.BasePackage,
 				altPkgs:         tt.fields.altPkgs,
 				CustomGenerator: tt.fields.CustomGenerator,
+				Overrides:       tt.fields.Overrides,
+				Layout:          tt.fields.Layout,
+			}
+			got, err := g.Generate()
+			if err != nil {
+				t.Fatalf("Generator.Generate() failed: %+v", err)
 			}
-			got := g.Generate()
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("Generator.Generate() differed: %s", diff)
 
@@ -110,3 +288,769 @@ func TestGenerator_Generate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_AltModuleKeywords(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Order": &rstypes.Struct{
+			Name: "example.com/models.Order",
+			Fields: map[string]rstypes.StructField{
+				"Kind":  {Type: &rstypes.Struct{Name: "example.com/models/kind.Kind"}},
+				"Scope": {Type: &rstypes.Struct{Name: "example.com/models/scope.Scope"}},
+			},
+		},
+		"example.com/models/kind.Kind":   &rstypes.Struct{Name: "example.com/models/kind.Kind", Fields: map[string]rstypes.StructField{}},
+		"example.com/models/scope.Scope": &rstypes.Struct{Name: "example.com/models/scope.Scope", Fields: map[string]rstypes.StructField{}},
+	})
+	g.BasePackage = "example.com/models"
+	g.Layout = LayoutModules
+	// Module names which are Rust keywords are raw identifiers
+	g.SetAltPackage("example.com/models/kind", "Type")
+	g.SetAltPackage("example.com/models/scope", "Self")
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	for _, want := range []string{
+		"pub mod r#type {\n",
+		"pub mod self_ {\n",
+		"\tpub kind: crate::r#type::Kind,\n",
+		"\tpub scope: crate::self_::Scope,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+
+	files, err := g.GenerateFiles()
+	if err != nil {
+		t.Fatalf("GenerateFiles() failed: %+v", err)
+	}
+	if _, ok := files["type/mod.rs"]; !ok {
+		t.Errorf("GenerateFiles() = %v, want type/mod.rs", files)
+	}
+}
+
+func TestGenerator_GenerateFiles(t *testing.T) {
+	g := &Generator{
+		types:       testdata.Data02,
+		BasePackage: "github.com/drewstone/go2rs/pkg/parser/testdata/conflict",
+		Layout:      LayoutModules,
+		ModuleRoot:  "crate::types",
+	}
+
+	want := map[string]string{
+		"mod.rs": `pub mod pkg;
+
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Data {
+	#[serde(rename = "Hoge")]
+	pub hoge: Hoge,
+	#[serde(rename = "PkgHoge")]
+	pub pkg_hoge: crate::types::pkg::Hoge,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Hoge {
+	#[serde(rename = "Data")]
+	pub data: i64,
+}
+
+`,
+		"pkg/mod.rs": `use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Hoge {
+	#[serde(rename = "Data")]
+	pub data: i64,
+}
+
+`,
+	}
+
+	got, err := g.GenerateFiles()
+	if err != nil {
+		t.Fatalf("Generator.GenerateFiles() failed: %+v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Generator.GenerateFiles() differed: %s", diff)
+	}
+}
+
+type RegisterItem struct {
+	Name string `json:"name"`
+}
+
+type RegisterTest struct {
+	Items []RegisterItem `json:"items"`
+}
+
+func TestGenerator_Register(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{})
+	if err := g.Register(&RegisterTest{}); err != nil {
+		t.Fatalf("Register() failed: %+v", err)
+	}
+
+	if err := g.Register(struct{}{}); err == nil {
+		t.Errorf("expected an error for an unnamed type")
+	}
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+	for _, want := range []string{
+		"pub struct RegisterTest {\n\t#[serde(rename = \"items\")]\n\tpub items: Option<Vec<RegisterItem>>,\n}",
+		"pub struct RegisterItem {\n\t#[serde(rename = \"name\")]\n\tpub name: String,\n}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+type RegisterHook struct {
+	Endpoint url.URL
+}
+
+func TestGenerator_Register_Wire(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{})
+	if err := g.Register(RegisterHook{}); err != nil {
+		t.Fatalf("Register() failed: %+v", err)
+	}
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	// The fields of url.URL, like its Userinfo, are not generated
+	want := "use serde::{Serialize, Deserialize};\nuse url::Url;\n\n" +
+		"#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]\n" +
+		"#[serde(rename_all = \"PascalCase\")]\n" +
+		"pub struct RegisterHook {\n" +
+		"\t#[serde(rename = \"Endpoint\")]\n" +
+		"\t#[serde(with = \"go_url\")]\n" +
+		"\tpub endpoint: Url,\n" +
+		"}\n\n" + g.adapterCode()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Generate() differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerator_Options(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{})
+	if err := g.Register(&RegisterTest{}); err != nil {
+		t.Fatalf("Register() failed: %+v", err)
+	}
+	g.Derives = []string{"Eq", "Debug"}
+	g.FieldNaming = FieldNamingPreserve
+	g.Exclude = []string{"RegisterItem"}
+	g.Include = []string{"Register*"}
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+	for _, want := range []string{
+		"#[derive(Debug, Clone, PartialEq, Serialize, Deserialize, Eq)]\n#[allow(non_snake_case)]\npub struct RegisterTest {\n\tpub items: Option<Vec<RegisterItem>>,\n}",
+		// Excluded types are still generated when selected types depend on them
+		"pub struct RegisterItem {\n\tpub name: String,\n}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() does not contain %q:\n%s", want, got)
+		}
+	}
+
+	g = NewGenerator(map[string]rstypes.Type{})
+	if err := g.Register(&RegisterItem{}); err != nil {
+		t.Fatalf("Register() failed: %+v", err)
+	}
+	g.Exclude = []string{"github.com/drewstone/go2rs/pkg/generator.*"}
+
+	if got, _ := g.Generate(); strings.Contains(got, "RegisterItem") {
+		t.Errorf("Generate() contains the excluded type:\n%s", got)
+	}
+}
+
+func TestGenerator_Diagnostics(t *testing.T) {
+	pos := &token.Position{Filename: "models/order.go", Line: 42}
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Order": &rstypes.Struct{
+			Name: "example.com/models.Order",
+			Fields: map[string]rstypes.StructField{
+				"Meta":   {Type: &rstypes.Stream{}, Position: pos},
+				"Totals": {Type: &rstypes.Map{Key: &rstypes.Boolean{}, Value: &rstypes.Number{}}},
+			},
+		},
+	})
+
+	_, err := g.Generate()
+	if _, ok := err.(DiagnosticsError); !ok {
+		t.Fatalf("Generate() error = %v, want DiagnosticsError", err)
+	}
+
+	got := make([]string, 0)
+	for _, d := range g.Diagnostics() {
+		got = append(got, d.Severity.String()+": "+d.String())
+	}
+
+	want := []string{
+		"error: models/order.go:42: field Meta has a channel or iterator type, which cannot be serialized",
+		"warning: example.com/models.Order: field Totals has map key type boolean, which cannot be a JSON object key",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diagnostics() differed: %s", diff)
+	}
+}
+
+func TestGenerator_Bytes(t *testing.T) {
+	bytesType := &rstypes.Nullable{Inner: &rstypes.Vec{Inner: &rstypes.Number{BitSize: 8}}}
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models/files.File": &rstypes.Struct{
+			Name: "example.com/models/files.File",
+			Fields: map[string]rstypes.StructField{
+				"Data":   {Type: bytesType},
+				"Chunks": {Type: &rstypes.Vec{Inner: bytesType}},
+			},
+		},
+	})
+	g.BasePackage = "example.com/models"
+	g.Layout = LayoutModules
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	for _, want := range []string{
+		"\t\t#[serde(with = \"crate::go_base64::option\")]\n\t\tpub data: Option<Vec<u8>>,\n",
+		"\t\tpub chunks: Vec<Option<Vec<u8>>>,\n",
+		"\npub mod go_base64 {\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+
+	diags := g.Diagnostics()
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "field Chunks has a []byte inside another type") {
+		t.Errorf("Diagnostics() = %v, want a warning about Chunks", diags)
+	}
+}
+
+func TestGenerator_WireTypes(t *testing.T) {
+	duration := &rstypes.Number{Common: rstypes.Common{GoType: "time.Duration"}, IsSigned: true, BitSize: 64}
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models/jobs.Job": &rstypes.Struct{
+			Name: "example.com/models/jobs.Job",
+			Fields: map[string]rstypes.StructField{
+				"Timeout":  {Type: duration},
+				"Backoff":  {Type: &rstypes.Nullable{Inner: &rstypes.Vec{Inner: duration}}},
+				"Endpoint": {Type: &rstypes.Struct{Common: rstypes.Common{GoType: "net/url.URL"}}},
+			},
+		},
+	})
+	g.BasePackage = "example.com/models"
+	g.Layout = LayoutModules
+	// Overrides take precedence over the built-in mappings
+	g.AddOverride("net/url.URL", Override{RustType: "String"})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	for _, want := range []string{
+		"\t\tuse std::time::Duration;\n",
+		"\t\t#[serde(with = \"crate::go_duration\")]\n\t\tpub timeout: Duration,\n",
+		"\t\tpub backoff: Option<Vec<Duration>>,\n",
+		"\t\tpub endpoint: String,\n",
+		"\npub mod go_duration {\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "go_url") {
+		t.Errorf("Generate() = %s, want no go_url adapter", got)
+	}
+
+	diags := g.Diagnostics()
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "field Backoff has a time.Duration inside another type") {
+		t.Errorf("Diagnostics() = %v, want a warning about Backoff", diags)
+	}
+}
+
+func TestGenerator_Traits(t *testing.T) {
+	handler := &rstypes.Trait{
+		Name: "example.com/models.Handler",
+		Methods: []*rstypes.Function{
+			{
+				Name:     "Handle",
+				IsMethod: true,
+				Returns:  &rstypes.Result{Ok: &rstypes.Unit{}, Err: &rstypes.Trait{Name: rstypes.ErrorTraitName}},
+			},
+		},
+	}
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Handler": handler,
+		"example.com/models.Route": &rstypes.Struct{
+			Name: "example.com/models.Route",
+			Fields: map[string]rstypes.StructField{
+				"Handler": {Type: handler},
+			},
+		},
+	})
+	g.ErrorType = "anyhow::Error"
+	g.AsyncTraits = true
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	// Interface fields hold dynamic values like in encoding/json
+	for _, want := range []string{
+		"use serde_json::Value;\n",
+		"\tasync fn handle(&self) -> Result<(), anyhow::Error>;\n",
+		"\tpub handler: Value,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+
+	diags := g.Diagnostics()
+	if len(diags) != 1 || diags[0].Severity != SeverityWarning ||
+		diags[0].String() != "example.com/models.Route: field Handler has interface type example.com/models.Handler, which is generated as Value" {
+		t.Errorf("Diagnostics() = %v", diags)
+	}
+}
+
+func TestGenerator_Streams(t *testing.T) {
+	event := &rstypes.Struct{
+		Name:   "example.com/models.Event",
+		Fields: map[string]rstypes.StructField{"Name": {Type: &rstypes.String{}}},
+	}
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Event": event,
+		"example.com/models.Source": &rstypes.Trait{
+			Name: "example.com/models.Source",
+			Methods: []*rstypes.Function{
+				{Name: "Events", IsMethod: true, Returns: &rstypes.Stream{Inner: event}},
+				{
+					Name:     "Publish",
+					IsMethod: true,
+					Params:   []rstypes.FunctionParam{{Name: "events", Type: &rstypes.Stream{Inner: event, Direction: rstypes.StreamSend}}},
+				},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if _, ok := err.(DiagnosticsError); !ok {
+		t.Fatalf("Generate() error = %v, want DiagnosticsError", err)
+	}
+
+	for _, want := range []string{
+		"use futures::stream::BoxStream;\n",
+		"\tfn events(&self) -> BoxStream<'static, Event>;\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+
+	diags := g.Diagnostics()
+	if len(diags) != 1 || diags[0].String() != "example.com/models.Source: method Publish has a send-only channel type, which has no Rust stream equivalent" {
+		t.Errorf("Diagnostics() = %v", diags)
+	}
+}
+
+func TestGenerator_DynamicType(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Event": &rstypes.Struct{
+			Name: "example.com/models.Event",
+			Fields: map[string]rstypes.StructField{
+				"Payload": {Type: &rstypes.Map{Key: &rstypes.String{}, Value: &rstypes.Any{}}},
+			},
+		},
+	})
+	g.DynamicType = "rmpv::Value"
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	for _, want := range []string{
+		"use std::collections::HashMap;\nuse rmpv::Value;\n",
+		"\tpub payload: HashMap<String, Value>,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+}
+
+func TestGenerator_DynamicTypeCollision(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Value": &rstypes.Struct{
+			Name: "example.com/models.Value",
+			Fields: map[string]rstypes.StructField{
+				"Raw":   {Type: &rstypes.Any{}},
+				"Attrs": {Type: &rstypes.Map{Key: &rstypes.String{}, Value: &rstypes.Any{}}},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	// The generated Value shadows the name of serde_json::Value, which is written in full
+	if strings.Contains(got, "use serde_json::Value;") {
+		t.Errorf("Generate() = %s, want serde_json::Value not to be imported", got)
+	}
+	for _, want := range []string{
+		"pub struct Value {\n",
+		"\tpub attrs: serde_json::Map<String, serde_json::Value>,\n",
+		"\tpub raw: serde_json::Value,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+
+	diags := g.Diagnostics()
+	want := "has Rust type serde_json::Value, which is not imported because Value already names the generated type Value"
+	if len(diags) != 1 || diags[0].Severity != SeverityWarning || diags[0].Message != want {
+		t.Errorf("Diagnostics() = %v, want a warning %q", diags, want)
+	}
+}
+
+func TestGenerator_Primitives(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Account": &rstypes.Struct{
+			Name: "example.com/models.Account",
+			Fields: map[string]rstypes.StructField{
+				"Key":      {Type: &rstypes.Primitive{Name: "uuid::Uuid"}},
+				"Balances": {Type: &rstypes.Primitive{Name: "HashMap<String, rust_decimal::Decimal>"}},
+				"Name":     {Type: &rstypes.Primitive{Name: "std::string::String"}},
+				"Limit":    {Type: &rstypes.Primitive{Name: "std::num::NonZeroU32"}},
+				"Flags":    {Type: &rstypes.Map{Key: &rstypes.Primitive{Name: "u8"}, Value: &rstypes.Primitive{Name: "bool"}}},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	for _, want := range []string{
+		"use std::collections::HashMap;\nuse rust_decimal::Decimal;\nuse std::num::NonZeroU32;\nuse uuid::Uuid;\n\n",
+		// Imported paths are referred to by their names
+		"\tpub key: Uuid,\n",
+		"\tpub balances: HashMap<String, Decimal>,\n",
+		"\tpub limit: NonZeroU32,\n",
+		"\tpub name: std::string::String,\n",
+		"\tpub flags: HashMap<u8, bool>,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+	if diags := g.Diagnostics(); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestGenerator_PrimitiveCollisions(t *testing.T) {
+	duration := &rstypes.Number{Common: rstypes.Common{GoType: "time.Duration"}, IsSigned: true, BitSize: 64}
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Job": &rstypes.Struct{
+			Name: "example.com/models.Job",
+			Fields: map[string]rstypes.StructField{
+				"Owner":   {Type: &rstypes.Primitive{Name: "a::Id"}},
+				"Parent":  {Type: &rstypes.Primitive{Name: "b::Id"}},
+				"Timeout": {Type: duration},
+				"Elapsed": {Type: &rstypes.Primitive{Name: "chrono::Duration"}},
+				"Run":     {Type: &rstypes.Primitive{Name: "runs::Job"}},
+				"Key":     {Type: &rstypes.Primitive{Name: "uuid::Uuid"}},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	// Colliding paths are written in full without being imported
+	want := "use serde::{Serialize, Deserialize};\nuse std::time::Duration;\nuse uuid::Uuid;\n\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("Generate() = %s, want to contain %q", got, want)
+	}
+	for _, want := range []string{
+		"\tpub owner: a::Id,\n",
+		"\tpub parent: b::Id,\n",
+		"\tpub timeout: Duration,\n",
+		"\tpub elapsed: chrono::Duration,\n",
+		"\tpub run: runs::Job,\n",
+		"\tpub key: Uuid,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() = %s, want to contain %q", got, want)
+		}
+	}
+
+	var messages []string
+	for _, d := range g.Diagnostics() {
+		if d.Severity != SeverityWarning {
+			t.Errorf("unexpected diagnostic: %v", d)
+		}
+		messages = append(messages, d.Message)
+	}
+	sort.Strings(messages)
+	wantMessages := []string{
+		"has Rust type a::Id, which is not imported because Id names other Rust types",
+		"has Rust type b::Id, which is not imported because Id names other Rust types",
+		"has Rust type chrono::Duration, which is not imported because Duration already names std::time::Duration",
+		"has Rust type runs::Job, which is not imported because Job already names the generated type Job",
+	}
+	if diff := cmp.Diff(wantMessages, messages); diff != "" {
+		t.Errorf("Diagnostics() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerator_Recursion(t *testing.T) {
+	const pkg = "example.com/models"
+	a := &rstypes.Struct{Name: pkg + ".A", Fields: map[string]rstypes.StructField{}}
+	b := &rstypes.Struct{Name: pkg + ".B", Fields: map[string]rstypes.StructField{}}
+	a.Fields["B"] = rstypes.StructField{Type: b}
+	b.Fields["A"] = rstypes.StructField{Type: &rstypes.Nullable{Inner: a}}
+
+	tests := []struct {
+		name  string
+		types map[string]rstypes.Type
+		want  string
+	}{
+		{
+			name: "tuple struct",
+			types: map[string]rstypes.Type{
+				pkg + ".Cons": &rstypes.Tuple{
+					Name: pkg + ".Cons",
+					Types: []rstypes.Type{
+						&rstypes.Number{IsSigned: true, BitSize: 64},
+						&rstypes.Nullable{Inner: &rstypes.Tuple{Name: pkg + ".Cons"}},
+					},
+				},
+			},
+			want: "pub struct Cons(pub i64, pub Option<Box<Cons>>);\n",
+		},
+		{
+			name: "enum variant",
+			types: map[string]rstypes.Type{
+				pkg + ".Expr": &rstypes.Enum{
+					Name: pkg + ".Expr",
+					Variants: map[string]rstypes.EnumVariant{
+						"Literal": {Type: &rstypes.Number{IsFloat: true, BitSize: 64}, FieldIndex: 0},
+						"Not":     {Type: &rstypes.Enum{Name: pkg + ".Expr"}, FieldIndex: 1},
+					},
+				},
+			},
+			want: "\tNot(Box<Expr>),\n",
+		},
+		{
+			name:  "mutual",
+			types: map[string]rstypes.Type{pkg + ".A": a, pkg + ".B": b},
+			// A is visited first, so B's reference back to it is boxed
+			want: "\tpub a: Option<Box<A>>,\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGenerator(tt.types).Generate()
+			if err != nil {
+				t.Fatalf("Generate() failed: %+v", err)
+			}
+
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("Generate() = %s, want to contain %q", got, tt.want)
+			}
+			if n := strings.Count(got, "Box<"); n != 1 {
+				t.Errorf("Generate() = %s, want exactly one boxed reference, got %d", got, n)
+			}
+		})
+	}
+}
+
+func TestGenerator_Constraints(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Range": &rstypes.Struct{
+			Name:       "example.com/models.Range",
+			TypeParams: []*rstypes.TypeParam{{Name: "T", Constraint: "cmp.Ordered", Comparable: true, Union: true}},
+			Fields: map[string]rstypes.StructField{
+				"Min": {Type: &rstypes.TypeParam{Name: "T"}},
+			},
+		},
+		"example.com/models.Labeled": &rstypes.Struct{
+			Name:       "example.com/models.Labeled",
+			TypeParams: []*rstypes.TypeParam{{Name: "T", Constraint: "fmt.Stringer", Methods: []string{"String"}}},
+			Fields: map[string]rstypes.StructField{
+				"Value": {Type: &rstypes.TypeParam{Name: "T"}},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+	if !strings.Contains(got, "pub struct Range<T> {") || !strings.Contains(got, "pub struct Labeled<T> {") {
+		t.Errorf("Generate() = %s, want the type parameters without bounds", got)
+	}
+
+	messages := make([]string, 0)
+	for _, d := range g.Diagnostics() {
+		if d.Severity != SeverityWarning {
+			t.Errorf("unexpected error: %v", d)
+		}
+		messages = append(messages, d.String())
+	}
+	sort.Strings(messages)
+
+	want := []string{
+		"example.com/models.Labeled: has type parameter T with constraint fmt.Stringer, which has no Rust equivalent",
+		"example.com/models.Range: has type parameter T with constraint cmp.Ordered, which has no Rust equivalent",
+	}
+	if diff := cmp.Diff(want, messages); diff != "" {
+		t.Errorf("Diagnostics() differed: %s", diff)
+	}
+}
+
+type NumberTest struct {
+	Int     int     `json:"int"`
+	Uint    uint    `json:"uint"`
+	Int8    int8    `json:"int8"`
+	Uint16  uint16  `json:"uint16"`
+	Float32 float32 `json:"float32"`
+	Float64 float64 `json:"float64"`
+}
+
+func TestGenerator_Numbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		intType  string
+		uintType string
+		want     []string
+	}{
+		{
+			name: "default",
+			want: []string{"\tpub float32: f32,\n", "\tpub float64: f64,\n", "\tpub int: i64,\n", "\tpub int8: i8,\n", "\tpub uint: u64,\n", "\tpub uint16: u16,\n"},
+		},
+		{
+			name:     "platform sized",
+			intType:  "isize",
+			uintType: "usize",
+			want:     []string{"\tpub float32: f32,\n", "\tpub float64: f64,\n", "\tpub int: isize,\n", "\tpub int8: i8,\n", "\tpub uint: usize,\n", "\tpub uint16: u16,\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(map[string]rstypes.Type{})
+			if err := g.Register(NumberTest{}); err != nil {
+				t.Fatalf("Register() failed: %+v", err)
+			}
+			g.IntType = tt.intType
+			g.UintType = tt.uintType
+
+			got, err := g.Generate()
+			if err != nil {
+				t.Fatalf("Generate() failed: %+v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Generate() does not contain %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_UnsizedNumbers(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/models.Point": &rstypes.Struct{
+			Name: "example.com/models.Point",
+			Fields: map[string]rstypes.StructField{
+				"X": {Type: &rstypes.Number{IsSigned: true}},
+				"Z": {Type: &rstypes.Number{IsFloat: true}},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+	for _, want := range []string{"\tpub x: i64,\n", "\tpub z: f64,\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestGenerator_EnumVariantTags(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/shape.Shape": &rstypes.Enum{
+			Name: "example.com/shape.Shape",
+			Variants: map[string]rstypes.EnumVariant{
+				"Circle": {FieldIndex: 0, RawTag: `json:"circle,omitempty"`, Type: &rstypes.Number{IsFloat: true, BitSize: 64}},
+				"Square": {FieldIndex: 1, RawTag: `yaml:"square"`, Type: &rstypes.Number{IsFloat: true, BitSize: 64}},
+				"Point":  {FieldIndex: 2, RawTag: `json:"-"`},
+			},
+		},
+	})
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	want := "pub enum Shape {\n\t#[serde(rename = \"circle\")]\n\tCircle(f64),\n\tSquare(f64),\n\tPoint,\n}"
+	if !strings.Contains(got, want) {
+		t.Errorf("Generate() does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestGenerator_EnumTagging(t *testing.T) {
+	g := NewGenerator(map[string]rstypes.Type{
+		"example.com/shape.Shape": &rstypes.Enum{
+			Name: "example.com/shape.Shape",
+			Variants: map[string]rstypes.EnumVariant{
+				"Point": {},
+			},
+		},
+	})
+	g.EnumTagging = rstypes.TaggingAdjacent
+	g.EnumContent = "data"
+
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %+v", err)
+	}
+
+	want := "#[serde(tag = \"type\", content = \"data\")]\npub enum Shape {\n\tPoint,\n}"
+	if !strings.Contains(got, want) {
+		t.Errorf("Generate() does not contain %q:\n%s", want, got)
+	}
+}
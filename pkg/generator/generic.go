@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// hashBound is the bound of type parameters used as HashMap keys, which serde derives do not add
+const hashBound = "Eq + std::hash::Hash"
+
+// genericDecl returns the declaration of the generic struct v is an instance of, if known
+func (g *Generator) genericDecl(v *rstypes.Struct) *rstypes.Struct {
+	decl, ok := g.types[v.Name].(*rstypes.Struct)
+	if !ok || len(decl.TypeParams) == 0 {
+		return nil
+	}
+
+	return decl
+}
+
+// inlineArg reports whether the i-th type argument of the instance v is stored by value,
+// which is assumed when the declaration is unknown
+func (g *Generator) inlineArg(v *rstypes.Struct, i int) bool {
+	decl := g.genericDecl(v)
+	if decl == nil || i >= len(decl.TypeParams) {
+		return true
+	}
+
+	name := decl.TypeParams[i].Name
+	for _, f := range g.structFields(decl) {
+		if storesParam(f.Type, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storesParam reports whether t stores the type parameter name by value
+func storesParam(t rstypes.Type, name string) bool {
+	switch v := t.(type) {
+	case *rstypes.TypeParam:
+		return v.Name == name
+	case *rstypes.Nullable:
+		return storesParam(v.Inner, name)
+	case *rstypes.Array:
+		return storesParam(v.Inner, name)
+	case *rstypes.Tuple:
+		if v.Name != "" {
+			return false
+		}
+		for _, elem := range v.Types {
+			if storesParam(elem, name) {
+				return true
+			}
+		}
+	case *rstypes.Struct:
+		for _, f := range v.Fields {
+			if v.Name == "" && storesParam(f.Type, name) {
+				return true
+			}
+		}
+		// Instances of other generic structs may store their arguments
+		for _, arg := range v.TypeArgs {
+			if storesParam(arg, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// typeArgs returns the type arguments of the instance v, e.g. <Order> in Page<Order>
+func (g *Generator) typeArgs(v *rstypes.Struct, fieldName string, typeStack []rstypes.Type) string {
+	if len(v.TypeArgs) == 0 {
+		return ""
+	}
+
+	args := make([]string, 0, len(v.TypeArgs))
+	for i, arg := range v.TypeArgs {
+		// Arguments the declaration does not store by value are behind its indirection
+		stack := typeStack
+		if !g.inlineArg(v, i) {
+			stack = append(stack, v)
+		}
+		args = append(args, g.GenerateTypeSimpleWithContext(arg, tupleElement(fieldName, i), stack))
+	}
+
+	return "<" + strings.Join(args, ", ") + ">"
+}
+
+// typeParams returns the type parameters of a generic struct declaration, e.g. <K: Eq + std::hash::Hash, V>.
+// serde derives add the Serialize and Deserialize bounds to their impls, so only the bounds the
+// fields need themselves are written. Constraints without a Rust equivalent are reported and dropped.
+func (g *Generator) typeParams(obj *rstypes.Struct) string {
+	if len(obj.TypeParams) == 0 {
+		return ""
+	}
+
+	params := make([]string, 0, len(obj.TypeParams))
+	for _, p := range obj.TypeParams {
+		if len(p.Methods) != 0 || p.Union {
+			g.report(SeverityWarning, obj, "has type parameter %s with constraint %s, which has no Rust equivalent", p.Name, p.Constraint)
+		}
+
+		if g.keyParam(obj, p.Name, make(map[*rstypes.Struct]bool)) {
+			params = append(params, p.Name+": "+hashBound)
+		} else {
+			params = append(params, p.Name)
+		}
+	}
+
+	return "<" + strings.Join(params, ", ") + ">"
+}
+
+// keyParam reports whether the type parameter name of the generic struct obj is used as a HashMap key
+func (g *Generator) keyParam(obj *rstypes.Struct, name string, seen map[*rstypes.Struct]bool) bool {
+	if seen[obj] {
+		return false
+	}
+	seen[obj] = true
+
+	var usedAsKey func(t rstypes.Type) bool
+	usedAsKey = func(t rstypes.Type) bool {
+		switch v := t.(type) {
+		case *rstypes.Map:
+			if p, ok := v.Key.(*rstypes.TypeParam); ok && p.Name == name {
+				return true
+			}
+			return usedAsKey(v.Key) || usedAsKey(v.Value)
+		case *rstypes.Nullable:
+			return usedAsKey(v.Inner)
+		case *rstypes.Vec:
+			return usedAsKey(v.Inner)
+		case *rstypes.Array:
+			return usedAsKey(v.Inner)
+		case *rstypes.Tuple:
+			for _, elem := range v.Types {
+				if usedAsKey(elem) {
+					return true
+				}
+			}
+		case *rstypes.Struct:
+			if v.Name == "" {
+				for _, f := range v.Fields {
+					if usedAsKey(f.Type) {
+						return true
+					}
+				}
+			}
+
+			// The parameter may be passed to a parameter of another generic struct used as a key
+			decl := g.genericDecl(v)
+			for i, arg := range v.TypeArgs {
+				if p, ok := arg.(*rstypes.TypeParam); ok && p.Name == name && decl != nil && i < len(decl.TypeParams) &&
+					g.keyParam(decl, decl.TypeParams[i].Name, seen) {
+					return true
+				}
+				if usedAsKey(arg) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, f := range g.structFields(obj) {
+		if usedAsKey(f.Type) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,290 @@
+package generator
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/drewstone/go2rs/pkg/util"
+)
+
+// Layout is how the generated types are organized into Rust modules
+type Layout int
+
+const (
+	// LayoutFlat generates all types into a single module and renames types whose names collide
+	LayoutFlat Layout = iota
+	// LayoutModules generates one Rust module per Go package, so types keep their Go names
+	LayoutModules
+)
+
+// rustKeywords are reserved in Rust and cannot be used as plain module names
+var rustKeywords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true, "continue": true,
+	"crate": true, "dyn": true, "else": true, "enum": true, "extern": true, "false": true,
+	"fn": true, "for": true, "if": true, "impl": true, "in": true, "let": true, "loop": true,
+	"match": true, "mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "Self": true, "static": true, "struct": true, "super": true,
+	"trait": true, "true": true, "type": true, "unsafe": true, "use": true, "where": true,
+	"while": true, "abstract": true, "become": true, "box": true, "do": true, "final": true,
+	"macro": true, "override": true, "priv": true, "try": true, "typeof": true,
+	"unsized": true, "virtual": true, "yield": true,
+}
+
+// moduleName converts a segment of a Go package path into a Rust module name
+func moduleName(segment string) string {
+	var buf bytes.Buffer
+	for i, r := range segment {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+			buf.WriteRune(unicode.ToLower(r))
+		case unicode.IsDigit(r):
+			if i == 0 {
+				buf.WriteByte('_')
+			}
+			buf.WriteRune(r)
+		default:
+			buf.WriteByte('_')
+		}
+	}
+
+	name := buf.String()
+	switch {
+	case name == "crate" || name == "self" || name == "super":
+		return name + "_"
+	case rustKeywords[name]:
+		return "r#" + name
+	}
+
+	return name
+}
+
+// modulePath returns the Rust module path, relative to the root module, for the Go package pkg
+func (g *Generator) modulePath(pkg string) string {
+	if alt, ok := g.altPkgs[pkg]; ok {
+		return moduleName(toSnakeCase(alt))
+	}
+
+	rel := pkg
+	switch {
+	case g.BasePackage != "" && pkg == g.BasePackage:
+		return ""
+	case g.BasePackage != "" && strings.HasPrefix(pkg, g.BasePackage+"/"):
+		rel = strings.TrimPrefix(pkg, g.BasePackage+"/")
+	}
+
+	segments := strings.Split(rel, "/")
+	for i := range segments {
+		segments[i] = moduleName(segments[i])
+	}
+
+	return strings.Join(segments, "::")
+}
+
+// moduleOf returns the module path of the fully qualified Go type name, which is always the root in LayoutFlat
+func (g *Generator) moduleOf(fullPath string) string {
+	if g.Layout == LayoutFlat || !strings.Contains(fullPath, ".") {
+		return ""
+	}
+
+	return g.modulePath(g.packageOf(fullPath))
+}
+
+// key returns the key of a type in nestedTypes and nestedEnums, which is its path relative to the root module
+func key(module, name string) string {
+	if module == "" {
+		return name
+	}
+
+	return module + "::" + name
+}
+
+// splitKey splits a key into the module path and the type name
+func splitKey(k string) (module, name string) {
+	idx := strings.LastIndex(k, "::")
+	if idx < 0 {
+		return "", k
+	}
+
+	return k[:idx], k[idx+2:]
+}
+
+// structKey returns the key of the named struct with the fully qualified name fullPath
+func (g *Generator) structKey(fullPath string) string {
+	if g.Layout == LayoutFlat {
+		return g.getTypeNameFromFullPath(fullPath)
+	}
+
+	_, name := util.SplitPackageStruct(fullPath)
+
+	return key(g.moduleOf(fullPath), name)
+}
+
+// enumKey returns the key of the named enum with the fully qualified name fullPath
+func (g *Generator) enumKey(fullPath string) string {
+	_, name := util.SplitPackageStruct(fullPath)
+
+	return key(g.moduleOf(fullPath), name)
+}
+
+// qualify returns how the type name in module is referred to from the module being generated
+func (g *Generator) qualify(module, name string) string {
+	if g.Layout == LayoutFlat || module == g.currentModule {
+		return name
+	}
+
+	root := g.ModuleRoot
+	if root == "" {
+		root = "crate"
+	}
+
+	return key(root, key(module, name))
+}
+
+// moduleTree returns the keys of enums and structs grouped by module, including modules without types
+func (g *Generator) moduleTree() (enums, structs map[string][]string, modules []string) {
+	enums = make(map[string][]string)
+	structs = make(map[string][]string)
+	all := map[string]bool{"": true}
+
+	addModule := func(module string) {
+		for module != "" && !all[module] {
+			all[module] = true
+			module, _ = splitKey(module)
+		}
+	}
+
+	for k := range g.nestedEnums {
+		module, _ := splitKey(k)
+		enums[module] = append(enums[module], k)
+		addModule(module)
+	}
+	for k := range g.nestedTypes {
+		module, _ := splitKey(k)
+		structs[module] = append(structs[module], k)
+		addModule(module)
+	}
+
+	for module := range all {
+		modules = append(modules, module)
+		sort.Strings(enums[module])
+		sort.Strings(structs[module])
+	}
+	sort.Strings(modules)
+
+	return enums, structs, modules
+}
+
+// childModules returns the direct children of module in modules
+func childModules(module string, modules []string) []string {
+	children := make([]string, 0)
+	for _, m := range modules {
+		if m == "" {
+			continue
+		}
+		if parent, _ := splitKey(m); parent == module {
+			children = append(children, m)
+		}
+	}
+
+	return children
+}
+
+// generateModuleTree generates every module inline as nested pub mod blocks
+func (g *Generator) generateModuleTree() string {
+	enums, structs, modules := g.moduleTree()
+
+	var generate func(module string) string
+	generate = func(module string) string {
+		buf := bytes.NewBuffer(nil)
+
+		if len(enums[module])+len(structs[module]) != 0 {
+			buf.WriteString(g.generateModule(module, enums[module], structs[module]))
+		}
+		if module == "" {
+			buf.WriteString(g.adapterCode())
+		}
+
+		for _, child := range childModules(module, modules) {
+			_, name := splitKey(child)
+
+			buf.WriteString("pub mod " + name + " {\n")
+			buf.WriteString(indent(strings.TrimSuffix(generate(child), "\n")))
+			buf.WriteString("}\n\n")
+		}
+
+		return buf.String()
+	}
+
+	return generate("")
+}
+
+// GenerateFiles generates one file per Rust module, keyed by slash separated paths like "billing/mod.rs".
+// The root module is "mod.rs". In LayoutFlat, all types are generated into "mod.rs".
+// Errors are reported like Generate.
+func (g *Generator) GenerateFiles() (map[string]string, error) {
+	if g.Layout == LayoutFlat {
+		generated, err := g.Generate()
+		return map[string]string{"mod.rs": generated}, err
+	}
+
+	g.diagnostics = nil
+	g.collectAllTypes()
+	enums, structs, modules := g.moduleTree()
+
+	files := make(map[string]string, len(modules))
+	for _, module := range modules {
+		buf := bytes.NewBuffer(nil)
+
+		children := childModules(module, modules)
+		for _, child := range children {
+			_, name := splitKey(child)
+			buf.WriteString("pub mod " + name + ";\n")
+		}
+		if len(children) != 0 {
+			buf.WriteString("\n")
+		}
+
+		if len(enums[module])+len(structs[module]) != 0 {
+			buf.WriteString(g.generateModule(module, enums[module], structs[module]))
+		}
+		if module == "" {
+			buf.WriteString(g.adapterCode())
+		}
+
+		files[moduleFile(module)] = buf.String()
+	}
+
+	return files, g.err()
+}
+
+// moduleFile returns the path of the file the module is generated into by GenerateFiles
+func moduleFile(module string) string {
+	if module == "" {
+		return "mod.rs"
+	}
+
+	return strings.ReplaceAll(strings.ReplaceAll(module, "r#", ""), "::", "/") + "/mod.rs"
+}
+
+// PackageFile returns the path of the file GenerateFiles generates the types of the Go package pkg into
+func (g *Generator) PackageFile(pkg string) string {
+	if g.Layout == LayoutFlat {
+		return "mod.rs"
+	}
+
+	return moduleFile(g.modulePath(pkg))
+}
+
+// indent indents every non-empty line by a tab
+func indent(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "\t" + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"path"
+	"strings"
+	"unicode"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// FieldNaming is how the Rust fields of structs are named
+type FieldNaming int
+
+const (
+	// FieldNamingSnakeCase converts field names into snake_case and renames them back in serde
+	FieldNamingSnakeCase FieldNaming = iota
+	// FieldNamingPreserve keeps the Go field names, so no renames are needed
+	FieldNamingPreserve
+)
+
+// derive returns the derive attribute with base followed by the extra Derives,
+// without PartialEq and the derives needing it when the type being generated cannot implement it
+func (g *Generator) derive(base ...string) string {
+	derives := append([]string{}, base...)
+
+	for _, d := range g.Derives {
+		dup := false
+		for _, b := range derives {
+			dup = dup || b == d
+		}
+		if !dup {
+			derives = append(derives, d)
+		}
+	}
+
+	if g.noPartialEq[g.node] {
+		kept := derives[:0]
+		for _, d := range derives {
+			if !partialEqDerives[d] {
+				kept = append(kept, d)
+			}
+		}
+		derives = kept
+	}
+
+	return "#[derive(" + strings.Join(derives, ", ") + ")]\n"
+}
+
+// fieldName returns the Rust name of the struct field field
+func (g *Generator) fieldName(field string) string {
+	if g.FieldNaming == FieldNamingPreserve {
+		return field
+	}
+
+	return toSnakeCase(field)
+}
+
+// needsRename reports whether the struct field field needs a serde rename when its Rust name is rustField.
+// Fields without one are named by rename_all, which only matches fields whose names are in PascalCase.
+func (g *Generator) needsRename(rustField, field string) bool {
+	if rustField != field {
+		return true
+	}
+
+	return g.FieldNaming != FieldNamingPreserve && pascalCase(rustField) != field
+}
+
+// pascalCase returns the name serde's rename_all = "PascalCase" gives the Rust field name
+func pascalCase(name string) string {
+	var buf strings.Builder
+	capitalize := true
+	for _, r := range strings.TrimPrefix(name, "r#") {
+		switch {
+		case r == '_':
+			capitalize = true
+		case capitalize:
+			buf.WriteRune(unicode.ToUpper(r))
+			capitalize = false
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
+// selected reports whether the top-level type name is generated according to Include and Exclude
+func (g *Generator) selected(name string) bool {
+	if len(g.Include) != 0 && !matchAny(g.Include, name) {
+		return false
+	}
+
+	return !matchAny(g.Exclude, name)
+}
+
+// matchAny reports whether name, with or without its package, matches any of the glob patterns
+func matchAny(patterns []string, name string) bool {
+	short := name
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		short = name[idx+1:]
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, short); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// numberType returns the Rust type of n.
+// Platform sized numbers, and numbers whose size is unknown, follow IntType and UintType.
+// Floats whose size is unknown are f64.
+func (g *Generator) numberType(n *rstypes.Number) string {
+	switch {
+	case n.IsFloat && n.BitSize == 0:
+		return "f64"
+	case n.IsFloat:
+		return n.String()
+	case n.BitSize != 0 && !n.IsUnsized:
+		return n.String()
+	case n.BitSize != 0 && !n.IsSigned:
+		if g.UintType != "" {
+			return g.UintType
+		}
+		return "u64"
+	default:
+		if g.IntType != "" {
+			return g.IntType
+		}
+		return "i64"
+	}
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// Override maps a Go named type onto an existing Rust type
+type Override struct {
+	// RustType is written wherever the Go type is used, e.g. "Decimal" or "uuid::Uuid"
+	RustType string
+	// Imports are the paths the Rust type needs in use declarations, e.g. "rust_decimal::Decimal"
+	Imports []string
+	// SerdeWith is the module set in #[serde(with = "...")] on fields of the type
+	SerdeWith string
+	// OptionSerdeWith is used instead of SerdeWith when the field is optional
+	OptionSerdeWith string
+}
+
+// Overrides maps fully qualified Go type names, e.g. "github.com/google/uuid.UUID", onto Rust types
+type Overrides map[string]Override
+
+// AddOverride maps the fully qualified Go type goType onto a Rust type
+func (g *Generator) AddOverride(goType string, o Override) {
+	if g.Overrides == nil {
+		g.Overrides = make(Overrides)
+	}
+	g.Overrides[goType] = o
+}
+
+// lookupOverride returns the override for the Go type t was derived from
+func (g *Generator) lookupOverride(t rstypes.Type) (Override, bool) {
+	if t == nil {
+		return Override{}, false
+	}
+
+	name := goTypeName(t)
+	if name == "" {
+		return Override{}, false
+	}
+
+	if o, ok := g.Overrides[name]; ok {
+		return o, true
+	}
+
+	// Standard library types are built in
+	return g.wireOverride(name)
+}
+
+// goTypeName returns the fully qualified name of the Go type t was derived from
+func goTypeName(t rstypes.Type) string {
+	if name := t.GetGoType(); name != "" {
+		return name
+	}
+
+	// Types built by hand often only carry their names
+	switch v := t.(type) {
+	case *rstypes.Struct:
+		return v.Name
+	case *rstypes.String:
+		return v.Name
+	case *rstypes.Number:
+		return v.Name
+	case *rstypes.Enum:
+		return v.Name
+	case *rstypes.Tuple:
+		return v.Name
+	case *rstypes.Trait:
+		return v.Name
+	}
+
+	return ""
+}
+
+// fieldSerdeWith returns the serde with module for a field of type t
+func (g *Generator) fieldSerdeWith(t rstypes.Type, optional bool) string {
+	// Named slices are overridden as a whole
+	o, ok := g.lookupOverride(t)
+	if !ok {
+		nullable, isNullable := t.(*rstypes.Nullable)
+		if !isNullable {
+			return ""
+		}
+		if o, ok = g.lookupOverride(nullable.Inner); !ok {
+			return ""
+		}
+		optional = true
+	}
+
+	if optional {
+		return o.OptionSerdeWith
+	}
+
+	return o.SerdeWith
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// rustPrelude are the types in scope in every Rust module, which are never imported
+var rustPrelude = map[string]bool{
+	"bool": true, "char": true, "str": true,
+	"i8": true, "i16": true, "i32": true, "i64": true, "i128": true, "isize": true,
+	"u8": true, "u16": true, "u32": true, "u64": true, "u128": true, "usize": true,
+	"f32": true, "f64": true,
+	"String": true, "Vec": true, "Option": true, "Box": true, "Result": true,
+}
+
+// primitiveSegments splits a Rust type like "HashMap<String, uuid::Uuid>" into its paths and the text between them
+func primitiveSegments(name string) []string {
+	isPath := func(r byte) bool {
+		return r == '_' || r == ':' || ('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+	}
+
+	segments := make([]string, 0)
+	start := 0
+	for i := 1; i <= len(name); i++ {
+		if i == len(name) || isPath(name[i]) != isPath(name[start]) {
+			segments = append(segments, name[start:i])
+			start = i
+		}
+	}
+
+	return segments
+}
+
+// isPathSegment reports whether s is a path returned by primitiveSegments rather than the text between paths
+func isPathSegment(s string) bool {
+	return s != "" && strings.Trim(s, "_:0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") == ""
+}
+
+// primitivePath returns the name a path in a Rust type is referred to by and the use declaration it needs, if any
+func primitivePath(path string) (string, string) {
+	path = strings.TrimPrefix(path, "::")
+
+	idx := strings.LastIndex(path, "::")
+	if idx == -1 {
+		return path, ""
+	}
+	name := path[idx+2:]
+
+	// The prelude is in scope however it is spelt
+	for _, std := range []string{"std::", "core::", "alloc::"} {
+		if strings.HasPrefix(path, std) && rustPrelude[name] {
+			return name, ""
+		}
+	}
+
+	return name, path
+}
+
+// primitiveType returns a Rust type written by hand with the paths imported in the module shortened to their names
+func (g *Generator) primitiveType(name string) string {
+	var buf strings.Builder
+	for _, s := range primitiveSegments(name) {
+		if short, path := primitivePath(s); isPathSegment(s) && g.uses[path] {
+			s = short
+		}
+		buf.WriteString(s)
+	}
+
+	return buf.String()
+}
+
+// addPrimitiveImports adds the paths in a Rust type written by hand to imports.
+// Names without a path, like HashMap, need the imports the generator writes for them.
+func addPrimitiveImports(imports *requiredImports, t *rstypes.Primitive) {
+	for _, s := range primitiveSegments(t.Name) {
+		if !isPathSegment(s) {
+			continue
+		}
+
+		short, path := primitivePath(s)
+		switch {
+		case path == "" && short == "HashMap":
+			imports.hasHashMap = true
+		case path == "" && (short == "DateTime" || short == "Utc"):
+			imports.hasDateTime = true
+		case path != "":
+			imports.addNamed(path, t)
+		}
+	}
+}
+
+// importedNames returns the names a use declaration like "chrono::{DateTime, Utc}" brings into scope
+func importedNames(path string) []string {
+	var names []string
+	if idx := strings.Index(path, "::{"); idx != -1 {
+		names = strings.Split(strings.TrimSuffix(path[idx+3:], "}"), ",")
+	} else {
+		names = []string{path}
+	}
+
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if idx := strings.LastIndex(name, " as "); idx != -1 {
+			name = name[idx+4:]
+		} else if idx := strings.LastIndex(name, "::"); idx != -1 {
+			name = name[idx+2:]
+		}
+		names[i] = name
+	}
+
+	return names
+}
+
+// addNamed adds path, found in t, to the paths which are imported unless their names collide
+func (i *requiredImports) addNamed(path string, t rstypes.Type) {
+	if _, ok := i.named[path]; !ok {
+		i.named[path] = t
+	}
+}
+
+// resolveNamedImports adds the use declarations of the paths in Primitive types and of the dynamic type to imports
+// unless their names collide with another import or with one of the types named in the module.
+// The paths which are not imported are written in full.
+func (g *Generator) resolveNamedImports(imports *requiredImports, names []string) {
+	// scope maps the names in scope to the paths or types they name
+	scope := make(map[string]string)
+	declare := func(name, path string) {
+		if _, ok := scope[name]; !ok {
+			scope[name] = path
+		}
+	}
+
+	for _, name := range names {
+		declare(name, "the generated type "+name)
+	}
+	declare("Serialize", "serde::Serialize")
+	declare("Deserialize", "serde::Deserialize")
+	if imports.hasHashMap {
+		declare("HashMap", "std::collections::HashMap")
+	}
+	if imports.hasDateTime {
+		declare("DateTime", "chrono::DateTime")
+		declare("Utc", "chrono::Utc")
+	}
+	for _, use := range imports.uses() {
+		for _, name := range importedNames(use) {
+			declare(name, use)
+		}
+	}
+
+	paths := make([]string, 0, len(imports.named))
+	for path := range imports.named {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Paths sharing a name are never imported, so neither depends on the order
+	shared := make(map[string]int)
+	for _, path := range paths {
+		shared[importedNames(path)[0]]++
+	}
+
+	for _, path := range paths {
+		name := importedNames(path)[0]
+		other, ok := scope[name]
+		switch {
+		case other == path:
+			// Already imported
+		case ok:
+			g.report(SeverityWarning, imports.named[path], "has Rust type %s, which is not imported because %s already names %s", path, name, other)
+		case shared[name] > 1:
+			g.report(SeverityWarning, imports.named[path], "has Rust type %s, which is not imported because %s names other Rust types", path, name)
+		default:
+			imports.paths[path] = true
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"sort"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// recursion is where recursive types need a Box to have a finite size in Rust
+type recursion struct {
+	// keys are the keys of the registered structs, tuple structs and data enums
+	keys map[rstypes.Type]string
+	// boxed are the target keys of the references from each key which are boxed
+	boxed map[string]map[string]bool
+}
+
+// nodeKey returns the key of a struct, tuple struct or data enum t is generated under, or "" for other types
+func (g *Generator) nodeKey(t rstypes.Type) string {
+	if key, ok := g.recursion.keys[t]; ok {
+		return key
+	}
+
+	// References to named types are often other values with the same name
+	switch v := t.(type) {
+	case *rstypes.Struct:
+		if v.Name != "" {
+			return g.structKey(v.Name)
+		}
+	case *rstypes.Tuple:
+		if v.Name != "" {
+			return g.structKey(v.Name)
+		}
+	case *rstypes.Enum:
+		if v.Name != "" {
+			return g.enumKey(v.Name)
+		}
+	}
+
+	return ""
+}
+
+// inlineRefs appends the keys of the types stored by value in t, which are not behind a Vec or a HashMap
+func (g *Generator) inlineRefs(refs []string, t rstypes.Type) []string {
+	if t == nil {
+		return refs
+	}
+	if _, ok := g.lookupOverride(t); ok {
+		return refs
+	}
+
+	switch v := t.(type) {
+	case *rstypes.Struct:
+		if key := g.nodeKey(v); key != "" {
+			refs = append(refs, key)
+		}
+		for i, arg := range v.TypeArgs {
+			if g.inlineArg(v, i) {
+				refs = g.inlineRefs(refs, arg)
+			}
+		}
+	case *rstypes.Enum:
+		if key := g.nodeKey(v); key != "" {
+			refs = append(refs, key)
+		}
+	case *rstypes.Tuple:
+		if v.Name != "" {
+			return append(refs, g.nodeKey(v))
+		}
+		for _, elem := range v.Types {
+			refs = g.inlineRefs(refs, elem)
+		}
+	case *rstypes.Nullable:
+		return g.inlineRefs(refs, v.Inner)
+	case *rstypes.Array:
+		return g.inlineRefs(refs, v.Inner)
+	}
+
+	return refs
+}
+
+// analyzeRecursion finds the references between generated types which need a Box.
+// Types stored by value in each other form cycles with an infinite size, which are broken
+// by boxing the back edges of a depth-first search, i.e. the references to types still being visited.
+// The search visits the keys in sorted order, which decides the reference boxed in each cycle,
+// e.g. B's reference to A rather than A's reference to B when A and B store each other.
+// Vec and HashMap already store their elements on the heap.
+func (g *Generator) analyzeRecursion() {
+	g.recursion = recursion{
+		keys:  make(map[rstypes.Type]string),
+		boxed: make(map[string]map[string]bool),
+	}
+
+	nodes := make(map[string]rstypes.Type)
+	for key, t := range g.nestedTypes {
+		switch t.(type) {
+		case *rstypes.Struct, *rstypes.Tuple:
+			nodes[key] = t
+		}
+	}
+	for key, t := range g.nestedEnums {
+		if _, ok := t.(*rstypes.Enum); ok {
+			nodes[key] = t
+		}
+	}
+	for key, t := range nodes {
+		g.recursion.keys[t] = key
+	}
+
+	edges := func(t rstypes.Type) []string {
+		refs := make([]string, 0)
+		switch v := t.(type) {
+		case *rstypes.Struct:
+			fields := g.structFields(v)
+			names := make([]string, 0, len(fields))
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				refs = g.inlineRefs(refs, fields[name].Type)
+			}
+		case *rstypes.Tuple:
+			for _, elem := range v.Types {
+				refs = g.inlineRefs(refs, elem)
+			}
+		case *rstypes.Enum:
+			names := make([]string, 0, len(v.Variants))
+			for name := range v.Variants {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				refs = g.inlineRefs(refs, v.Variants[name].Type)
+			}
+		}
+
+		return refs
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = visiting
+		for _, ref := range edges(nodes[key]) {
+			if _, ok := nodes[ref]; !ok {
+				continue
+			}
+
+			switch state[ref] {
+			case unvisited:
+				visit(ref)
+			case visiting:
+				if g.recursion.boxed[key] == nil {
+					g.recursion.boxed[key] = make(map[string]bool)
+				}
+				g.recursion.boxed[key][ref] = true
+			}
+		}
+		state[key] = visited
+	}
+
+	keys := make([]string, 0, len(nodes))
+	for key := range nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+}
+
+// boxed returns typ in a Box when t is referred to recursively by value from the type being generated.
+// Types in a Vec, a HashMap or a generic struct which does not store them by value are not boxed.
+func (g *Generator) boxed(t rstypes.Type, typ string, typeStack []rstypes.Type) string {
+	for _, outer := range typeStack {
+		switch outer.(type) {
+		case *rstypes.Vec, *rstypes.Map, *rstypes.Struct:
+			return typ
+		}
+	}
+
+	if !g.recursion.boxed[g.node][g.nodeKey(t)] {
+		return typ
+	}
+
+	return "Box<" + typ + ">"
+}
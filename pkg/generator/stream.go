@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"fmt"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// streamImport is imported by modules with streams
+const streamImport = "futures::stream::BoxStream"
+
+// streamType returns the Rust type of a stream, which is a boxed futures stream in method signatures.
+// Streams cannot be serialized, and only receive-only channels and iterators have a Rust equivalent.
+func (g *Generator) streamType(s *rstypes.Stream, fieldName string, typeStack []rstypes.Type) string {
+	switch {
+	case !g.signature:
+		g.report(SeverityError, s, "has a channel or iterator type, which cannot be serialized")
+		return "Unknown"
+	case s.Direction == rstypes.StreamSend:
+		g.report(SeverityError, s, "has a send-only channel type, which has no Rust stream equivalent")
+		return "Unknown"
+	case s.Direction == rstypes.StreamBoth:
+		g.report(SeverityError, s, "has a bidirectional channel type, which has no Rust stream equivalent; use a receive-only channel")
+		return "Unknown"
+	}
+
+	inner := g.GenerateTypeSimpleWithContext(s.Inner, fieldName, typeStack)
+
+	return fmt.Sprintf("BoxStream<'static, %s>", inner)
+}
@@ -57,7 +57,7 @@ var (
 					},
 				},
 				"EnumArray": {
-					Type: &types.Array{
+					Type: &types.Vec{
 						Inner: &types.String{
 							Enum: []string{"a", "b", "c"},
 						},
@@ -65,7 +65,7 @@ var (
 				},
 				"Array": {
 					Type: &types.Nullable{
-						Inner: &types.Array{
+						Inner: &types.Vec{
 							Inner: &types.Number{},
 						},
 					},
@@ -80,7 +80,7 @@ var (
 					},
 				},
 				"OptionalArray": {
-					Type: &types.Array{
+					Type: &types.Vec{
 						Inner: &types.Nullable{
 							Inner: &types.String{},
 						},
@@ -3,17 +3,20 @@ use std::collections::HashMap;
 use chrono::{DateTime, Utc};
 
 #[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]
-#[serde(rename_all = "lowercase")]
-pub enum EnumArrayValues {
+pub enum EnumArray {
+	#[serde(rename = "a")]
 	A,
+	#[serde(rename = "b")]
 	B,
+	#[serde(rename = "c")]
 	C,
 }
 
 #[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]
-#[serde(rename_all = "PascalCase")]
 pub enum Status {
+	#[serde(rename = "Failure")]
 	Failure,
+	#[serde(rename = "OK")]
 	OK,
 }
 
@@ -21,15 +24,15 @@ pub enum Status {
 #[serde(rename_all = "PascalCase")]
 pub struct Data {
 	#[serde(rename = "A")]
-	pub a: u128,
+	pub a: i64,
 	#[serde(rename = "Array")]
-	pub array: Option<Vec<u128>>,
+	pub array: Option<Vec<i64>>,
 	#[serde(rename = "C")]
 	pub c: String,
 	#[serde(rename = "D")]
-	pub d: Option<u128>,
+	pub d: Option<i64>,
 	#[serde(rename = "EnumArray")]
-	pub enum_array: Vec<EnumArrayValues>,
+	pub enum_array: Vec<EnumArray>,
 	#[serde(skip_serializing_if = "Option::is_none")]
 	pub Foo: Option<Foo>,
 	#[serde(rename = "Map")]
@@ -45,35 +48,39 @@ pub struct Data {
 	#[serde(rename = "U")]
 	pub u: U,
 	#[serde(skip_serializing_if = "Option::is_none")]
-	pub b: Option<u128>,
+	#[serde(rename = "b")]
+	pub b: Option<i64>,
 	#[serde(skip_serializing_if = "Option::is_none")]
-	pub foo: Option<u128>,
+	#[serde(rename = "foo")]
+	pub foo: Option<i64>,
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 #[serde(rename_all = "PascalCase")]
 pub struct Embedded {
 	#[serde(skip_serializing_if = "Option::is_none")]
-	pub foo: Option<u128>,
+	#[serde(rename = "foo")]
+	pub foo: Option<i64>,
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 #[serde(rename_all = "PascalCase")]
 pub struct Foo {
 	#[serde(rename = "V")]
-	pub v: u128,
+	pub v: i64,
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 #[serde(rename_all = "PascalCase")]
 pub struct Package {
-	pub data: u128,
+	#[serde(rename = "data")]
+	pub data: i64,
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 #[serde(rename_all = "PascalCase")]
 pub struct U {
 	#[serde(rename = "Data")]
-	pub data: u128,
+	pub data: i64,
 }
 
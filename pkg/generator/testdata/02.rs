@@ -13,13 +13,13 @@ pub struct Data {
 #[serde(rename_all = "PascalCase")]
 pub struct Hoge {
 	#[serde(rename = "Data")]
-	pub data: u128,
+	pub data: i64,
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 #[serde(rename_all = "PascalCase")]
 pub struct PkgHoge {
 	#[serde(rename = "Data")]
-	pub data: u128,
+	pub data: i64,
 }
 
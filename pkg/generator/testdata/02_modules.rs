@@ -0,0 +1,29 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Data {
+	#[serde(rename = "Hoge")]
+	pub hoge: Hoge,
+	#[serde(rename = "PkgHoge")]
+	pub pkg_hoge: crate::pkg::Hoge,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Hoge {
+	#[serde(rename = "Data")]
+	pub data: i64,
+}
+
+pub mod pkg {
+	use serde::{Serialize, Deserialize};
+
+	#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+	#[serde(rename_all = "PascalCase")]
+	pub struct Hoge {
+		#[serde(rename = "Data")]
+		pub data: i64,
+	}
+}
+
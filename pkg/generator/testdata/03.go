@@ -12,7 +12,7 @@ var (
 			Fields: map[string]types.StructField{
 				"Re": {}, // Overwritten by init()
 				"Children": {
-					Type: &types.Array{
+					Type: &types.Vec{
 						Inner: &types.Struct{
 							Name: "github.com/drewstone/go2rs/pkg/parser/testdata/recursive.Recursive",
 						},
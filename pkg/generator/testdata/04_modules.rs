@@ -0,0 +1,25 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Data {
+}
+
+pub mod testdata {
+	use serde::{Serialize, Deserialize};
+
+	#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+	#[serde(rename_all = "PascalCase")]
+	pub struct Data {
+	}
+
+	pub mod testdata {
+		use serde::{Serialize, Deserialize};
+
+		#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+		#[serde(rename_all = "PascalCase")]
+		pub struct Data {
+		}
+	}
+}
+
@@ -0,0 +1,56 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	// Data06 - 06.rs
+	Data06 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/override.Payment": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/override.Payment",
+			Fields: map[string]types.StructField{
+				"ID": {
+					Type: &types.Array{
+						Common: types.Common{GoType: "github.com/google/uuid.UUID"},
+						Inner:  &types.Number{},
+						Size:   16,
+					},
+				},
+				"Amount": {
+					Type: &types.Struct{
+						Name:   "github.com/shopspring/decimal.Decimal",
+						Fields: map[string]types.StructField{},
+					},
+				},
+				"Fee": {
+					Optional: true,
+					Type: &types.Struct{
+						Name:   "github.com/shopspring/decimal.Decimal",
+						Fields: map[string]types.StructField{},
+					},
+				},
+				"Refund": {
+					Type: &types.Nullable{
+						Inner: &types.Struct{
+							Name:   "github.com/shopspring/decimal.Decimal",
+							Fields: map[string]types.StructField{},
+						},
+					},
+				},
+				"Related": {
+					Type: &types.Map{
+						Key: &types.String{},
+						Value: &types.Array{
+							Common: types.Common{GoType: "github.com/google/uuid.UUID"},
+							Inner:  &types.Number{},
+							Size:   16,
+						},
+					},
+				},
+			},
+		},
+		"github.com/shopspring/decimal.Decimal": &types.Struct{
+			Name:   "github.com/shopspring/decimal.Decimal",
+			Fields: map[string]types.StructField{},
+		},
+	}
+)
@@ -0,0 +1,24 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+use rust_decimal::Decimal;
+use uuid::Uuid;
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Payment {
+	#[serde(rename = "Amount")]
+	#[serde(with = "rust_decimal::serde::str")]
+	pub amount: Decimal,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Fee")]
+	#[serde(default, with = "rust_decimal::serde::str_option")]
+	pub fee: Option<Decimal>,
+	#[serde(rename = "ID")]
+	pub i_d: Uuid,
+	#[serde(rename = "Refund")]
+	#[serde(with = "rust_decimal::serde::str_option")]
+	pub refund: Option<Decimal>,
+	#[serde(rename = "Related")]
+	pub related: HashMap<String, Uuid>,
+}
+
@@ -0,0 +1,68 @@
+package testdata
+
+import (
+	gotypes "go/types"
+
+	types "github.com/drewstone/go2rs/pkg/types"
+)
+
+var (
+	priority = func() *types.Number {
+		num := &types.Number{Name: "github.com/drewstone/go2rs/pkg/parser/testdata/iota.Priority"}
+		num.SetRawType(gotypes.Int)
+		num.AddCandidates("PriorityLow", int64(0))
+		num.AddCandidates("PriorityHigh", int64(2))
+		num.AddCandidates("PriorityUrgent", int64(10))
+		num.AddCandidates("PriorityDefault", int64(0))
+
+		return num
+	}()
+
+	offset = func() *types.Number {
+		num := &types.Number{Name: "github.com/drewstone/go2rs/pkg/parser/testdata/iota.Offset"}
+		num.SetRawType(gotypes.Int)
+		num.AddCandidates("Behind", int64(-1))
+		num.AddCandidates("OffsetNone", int64(0))
+		num.AddCandidates("OffsetFar", int64(5000000000))
+		// Named Far like OffsetFar without the type name
+		num.AddCandidates("Far", int64(100))
+
+		return num
+	}()
+
+	flag = func() *types.Number {
+		num := &types.Number{Name: "github.com/drewstone/go2rs/pkg/parser/testdata/iota.Flag"}
+		num.SetRawType(gotypes.Uint8)
+		num.AddCandidates("FlagRead", uint64(1))
+		num.AddCandidates("FlagWrite", uint64(2))
+		num.AddCandidates("FlagExec", uint64(4))
+
+		return num
+	}()
+
+	// Data07 - 07.rs
+	Data07 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/iota.Priority": priority,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/iota.Offset":   offset,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/iota.Flag":     flag,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/iota.Task": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/iota.Task",
+			Fields: map[string]types.StructField{
+				"Priority": {
+					Type: priority,
+				},
+				"Offset": {
+					Optional: true,
+					Type:     offset,
+				},
+				"Flags": {
+					Type: &types.Nullable{
+						Inner: &types.Vec{
+							Inner: flag,
+						},
+					},
+				},
+			},
+		},
+	}
+)
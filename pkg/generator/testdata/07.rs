@@ -0,0 +1,40 @@
+use serde::{Serialize, Deserialize};
+use serde_repr::{Serialize_repr, Deserialize_repr};
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum Flag {
+	Read = 1,
+	Write = 2,
+	Exec = 4,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize_repr, Deserialize_repr)]
+#[repr(i64)]
+pub enum Offset {
+	Behind = -1,
+	None = 0,
+	Far = 5000000000,
+	Far2 = 100,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize_repr, Deserialize_repr)]
+#[repr(i32)]
+pub enum Priority {
+	Low = 0,
+	High = 2,
+	Urgent = 10,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Task {
+	#[serde(rename = "Flags")]
+	pub flags: Option<Vec<Flag>>,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Offset")]
+	pub offset: Option<Offset>,
+	#[serde(rename = "Priority")]
+	pub priority: Priority,
+}
+
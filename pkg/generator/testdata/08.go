@@ -0,0 +1,48 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	taskState = func() *types.String {
+		str := &types.String{Name: "github.com/drewstone/go2rs/pkg/parser/testdata/enum.TaskState"}
+		str.AddCandidates("TaskStateInProgress", "in-progress")
+		str.AddCandidates("TaskStateDone", "done")
+		str.AddCandidates("TaskStateUnknown", "")
+		str.AddCandidates("TaskStateFinished", "done")
+
+		return str
+	}()
+
+	method = func() *types.String {
+		str := &types.String{Name: "github.com/drewstone/go2rs/pkg/parser/testdata/enum.Method"}
+		str.AddCandidates("Method2FA", "2fa")
+		str.AddCandidates("MethodTOTP", "totp")
+		str.AddCandidates("Password", "password")
+
+		return str
+	}()
+
+	// Data08 - 08.rs
+	Data08 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/enum.TaskState": taskState,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/enum.Method":    method,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/enum.Login": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/enum.Login",
+			Fields: map[string]types.StructField{
+				"State": {
+					Type: taskState,
+				},
+				"Methods": {
+					Type: &types.Vec{
+						Inner: method,
+					},
+				},
+				"Kind": {
+					Type: &types.String{
+						Enum: []string{"read-only", "2nd", ""},
+					},
+				},
+			},
+		},
+	}
+)
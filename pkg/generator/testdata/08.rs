@@ -0,0 +1,43 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]
+pub enum Kind {
+	#[serde(rename = "read-only")]
+	ReadOnly,
+	#[serde(rename = "2nd")]
+	V2nd,
+	#[serde(rename = "")]
+	Empty,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]
+pub enum Method {
+	#[serde(rename = "2fa")]
+	Method2FA,
+	#[serde(rename = "totp")]
+	TOTP,
+	#[serde(rename = "password")]
+	Password,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]
+pub enum TaskState {
+	#[serde(rename = "in-progress")]
+	InProgress,
+	#[serde(rename = "done")]
+	Done,
+	#[serde(rename = "")]
+	Unknown,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Login {
+	#[serde(rename = "Kind")]
+	pub kind: Kind,
+	#[serde(rename = "Methods")]
+	pub methods: Vec<Method>,
+	#[serde(rename = "State")]
+	pub state: TaskState,
+}
+
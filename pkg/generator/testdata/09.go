@@ -0,0 +1,84 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	circle = &types.Struct{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Circle",
+		Fields: map[string]types.StructField{
+			"Radius": {Type: &types.Number{IsFloat: true, IsSigned: true, BitSize: 64}},
+		},
+	}
+
+	square = &types.Struct{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Square",
+		Fields: map[string]types.StructField{
+			"Side": {Type: &types.Number{IsFloat: true, IsSigned: true, BitSize: 64}},
+		},
+	}
+
+	// Data09 - 09.rs
+	Data09 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Circle": circle,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Square": square,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Shape": &types.Enum{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Shape",
+			Variants: map[string]types.EnumVariant{
+				"Square": {FieldIndex: 1, Type: square},
+				"Circle": {FieldIndex: 0, Type: circle},
+				"Empty":  {FieldIndex: 2},
+			},
+		},
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Event": &types.Enum{
+			Name:    "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Event",
+			Tagging: types.TaggingInternal,
+			Tag:     "kind",
+			Variants: map[string]types.EnumVariant{
+				"Created": {FieldIndex: 0, RawTag: `json:"created"`, Type: circle},
+				"Resized": {
+					FieldIndex: 1,
+					RawTag:     `json:"resized"`,
+					Type: &types.Struct{
+						Fields: map[string]types.StructField{
+							"Scale": {Type: &types.Number{IsFloat: true, IsSigned: true, BitSize: 32}},
+						},
+					},
+				},
+				"Deleted": {FieldIndex: 2, RawTag: `json:"deleted"`},
+			},
+		},
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Message": &types.Enum{
+			Name:    "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Message",
+			Tagging: types.TaggingAdjacent,
+			Tag:     "t",
+			Content: "c",
+			Variants: map[string]types.EnumVariant{
+				"Text":  {FieldIndex: 0, Type: &types.String{}},
+				"Shape": {FieldIndex: 1, Type: square},
+			},
+		},
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Value": &types.Enum{
+			Name:    "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Value",
+			Tagging: types.TaggingUntagged,
+			Variants: map[string]types.EnumVariant{
+				"Number": {FieldIndex: 0, Type: &types.Number{IsFloat: true, IsSigned: true, BitSize: 64}},
+				"Text":   {FieldIndex: 1, Type: &types.String{}},
+				"List": {FieldIndex: 2, Type: &types.Vec{
+					Inner: &types.String{},
+				}},
+			},
+		},
+		"github.com/drewstone/go2rs/pkg/parser/testdata/shape.Drawing": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Drawing",
+			Fields: map[string]types.StructField{
+				"Shapes": {
+					Type: &types.Vec{
+						Inner: &types.Enum{
+							Name: "github.com/drewstone/go2rs/pkg/parser/testdata/shape.Shape",
+						},
+					},
+				},
+			},
+		},
+	}
+)
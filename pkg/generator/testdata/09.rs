@@ -0,0 +1,63 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(tag = "kind")]
+pub enum Event {
+	#[serde(rename = "created")]
+	Created(Circle),
+	#[serde(rename = "resized")]
+	Resized(Resized),
+	#[serde(rename = "deleted")]
+	Deleted,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(tag = "t", content = "c")]
+pub enum Message {
+	Text(String),
+	Shape(Square),
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub enum Shape {
+	Circle(Circle),
+	Square(Square),
+	Empty,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(untagged)]
+pub enum Value {
+	Number(f64),
+	Text(String),
+	List(Vec<String>),
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Circle {
+	#[serde(rename = "Radius")]
+	pub radius: f64,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Drawing {
+	#[serde(rename = "Shapes")]
+	pub shapes: Vec<Shape>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Resized {
+	#[serde(rename = "Scale")]
+	pub scale: f32,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Square {
+	#[serde(rename = "Side")]
+	pub side: f64,
+}
+
@@ -0,0 +1,69 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	point = &types.Tuple{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/geo.Point",
+		Types: []types.Type{
+			&types.Number{IsFloat: true, IsSigned: true, BitSize: 64},
+			&types.Number{IsFloat: true, IsSigned: true, BitSize: 64},
+		},
+	}
+
+	label = &types.Tuple{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/geo.Label",
+		Types: []types.Type{
+			&types.String{},
+		},
+	}
+
+	// Data10 - 10.rs
+	Data10 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/geo.Point": point,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/geo.Label": label,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/geo.Track": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/geo.Track",
+			Fields: map[string]types.StructField{
+				"Points": {Type: &types.Vec{Inner: point}},
+				"Origin": {Type: point, Optional: true},
+				"Places": {Type: &types.Map{Key: &types.String{}, Value: point}},
+				"Label":  {Type: label},
+				"Samples": {
+					Type: &types.Vec{
+						Inner: &types.Tuple{
+							Types: []types.Type{
+								&types.Number{IsSigned: true, BitSize: 64},
+								&types.Number{IsFloat: true, IsSigned: true, BitSize: 64},
+							},
+						},
+					},
+				},
+				"Bounds": {
+					Type: &types.Nullable{
+						Inner: &types.Tuple{
+							Types: []types.Type{point, point},
+						},
+					},
+				},
+				"Single": {
+					Type: &types.Tuple{
+						Types: []types.Type{&types.Boolean{}},
+					},
+				},
+				"Stop": {
+					Type: &types.Tuple{
+						Types: []types.Type{
+							&types.Date{},
+							&types.Struct{
+								Fields: map[string]types.StructField{
+									"Name": {Type: &types.String{}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+)
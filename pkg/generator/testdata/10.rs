@@ -0,0 +1,39 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+use chrono::{DateTime, Utc};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Label(pub (String,));
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Point(pub f64, pub f64);
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Stop1 {
+	#[serde(rename = "Name")]
+	pub name: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Track {
+	#[serde(rename = "Bounds")]
+	pub bounds: Option<(Point, Point)>,
+	#[serde(rename = "Label")]
+	pub label: Label,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Origin")]
+	pub origin: Option<Point>,
+	#[serde(rename = "Places")]
+	pub places: HashMap<String, Point>,
+	#[serde(rename = "Points")]
+	pub points: Vec<Point>,
+	#[serde(rename = "Samples")]
+	pub samples: Vec<(i64, f64)>,
+	#[serde(rename = "Single")]
+	pub single: (bool,),
+	#[serde(rename = "Stop")]
+	pub stop: (DateTime<Utc>, Stop1),
+}
+
@@ -0,0 +1,49 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	byteType = &types.Number{BitSize: 8}
+
+	signature = &types.Tuple{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/crypto.Signature",
+		Types: []types.Type{
+			&types.Array{Inner: byteType, Size: 64},
+		},
+	}
+
+	// Data11 - 11.rs
+	Data11 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/crypto.Signature": signature,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/crypto.Block": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/crypto.Block",
+			Fields: map[string]types.StructField{
+				"Hash":      {Type: &types.Array{Inner: byteType, Size: 32}},
+				"Color":     {Type: &types.Array{Inner: &types.Number{IsFloat: true, IsSigned: true, BitSize: 32}, Size: 4}},
+				"Signature": {Type: signature},
+				"PublicKey": {Type: &types.Array{Inner: byteType, Size: 64}},
+				"Parents": {
+					Type: &types.Nullable{
+						Inner: &types.Vec{Inner: &types.Array{Inner: byteType, Size: 32}},
+					},
+				},
+				"Proof": {
+					Optional: true,
+					Type:     &types.Array{Inner: byteType, Size: 96},
+				},
+				"Witnesses": {
+					Type: &types.Map{
+						Key:   &types.String{},
+						Value: &types.Array{Inner: byteType, Size: 48},
+					},
+				},
+				"Matrix": {
+					Type: &types.Array{
+						Inner: &types.Array{Inner: &types.Number{IsFloat: true, IsSigned: true, BitSize: 32}, Size: 4},
+						Size:  4,
+					},
+				},
+			},
+		},
+	}
+)
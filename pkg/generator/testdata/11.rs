@@ -0,0 +1,34 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+use serde_with::serde_as;
+
+#[serde_as]
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Block {
+	#[serde(rename = "Color")]
+	pub color: [f32; 4],
+	#[serde(rename = "Hash")]
+	pub hash: [u8; 32],
+	#[serde(rename = "Matrix")]
+	pub matrix: [[f32; 4]; 4],
+	#[serde(rename = "Parents")]
+	pub parents: Option<Vec<[u8; 32]>>,
+	#[serde_as(as = "Option<[_; 96]>")]
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Proof")]
+	pub proof: Option<[u8; 96]>,
+	#[serde_as(as = "[_; 64]")]
+	#[serde(rename = "PublicKey")]
+	pub public_key: [u8; 64],
+	#[serde(rename = "Signature")]
+	pub signature: Signature,
+	#[serde_as(as = "HashMap<_, [_; 48]>")]
+	#[serde(rename = "Witnesses")]
+	pub witnesses: HashMap<String, [u8; 48]>,
+}
+
+#[serde_as]
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Signature(#[serde_as(as = "([_; 64],)")] pub ([u8; 64],));
+
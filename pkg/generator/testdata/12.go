@@ -0,0 +1,30 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	blobBytes = &types.Vec{
+		Common: types.Common{GoType: "github.com/drewstone/go2rs/pkg/parser/testdata/blob.Blob"},
+		Inner:  &types.Number{BitSize: 8},
+	}
+
+	blob = &types.Nullable{
+		Common: types.Common{GoType: "github.com/drewstone/go2rs/pkg/parser/testdata/blob.Blob"},
+		Inner:  blobBytes,
+	}
+
+	// Data12 - 12.rs
+	Data12 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/blob.Blob": blob,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/blob.Message": &types.Struct{
+			Name: "github.com/drewstone/go2rs/pkg/parser/testdata/blob.Message",
+			Fields: map[string]types.StructField{
+				"Signature": {Type: &types.Nullable{Inner: &types.Vec{Inner: &types.Number{BitSize: 8}}}},
+				"Nonce":     {Type: &types.Vec{Inner: &types.Number{BitSize: 8}}},
+				"Body":      {Type: blobBytes, Optional: true},
+				"Hash":      {Type: &types.Array{Inner: &types.Number{BitSize: 8}, Size: 32}},
+				"Counts":    {Type: &types.Vec{Inner: &types.Number{BitSize: 16}}},
+			},
+		},
+	}
+)
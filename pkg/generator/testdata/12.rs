@@ -0,0 +1,54 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Message {
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Body")]
+	#[serde(default, with = "go_base64::option")]
+	pub body: Option<Vec<u8>>,
+	#[serde(rename = "Counts")]
+	pub counts: Vec<u16>,
+	#[serde(rename = "Hash")]
+	pub hash: [u8; 32],
+	#[serde(rename = "Nonce")]
+	#[serde(with = "go_base64")]
+	pub nonce: Vec<u8>,
+	#[serde(rename = "Signature")]
+	#[serde(with = "go_base64::option")]
+	pub signature: Option<Vec<u8>>,
+}
+
+/// Serializes Vec<u8> as a base64 string like Go's encoding/json
+pub mod go_base64 {
+	use base64::{engine::general_purpose::STANDARD, Engine as _};
+	use serde::{Deserialize, Deserializer, Serializer};
+
+	pub fn serialize<S: Serializer>(bytes: &[u8], serializer: S) -> Result<S::Ok, S::Error> {
+		serializer.serialize_str(&STANDARD.encode(bytes))
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Vec<u8>, D::Error> {
+		let s = String::deserialize(deserializer)?;
+		STANDARD.decode(s).map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<Vec<u8>> as a base64 string or null
+	pub mod option {
+		use base64::{engine::general_purpose::STANDARD, Engine as _};
+		use serde::{Deserialize, Deserializer, Serializer};
+
+		pub fn serialize<S: Serializer>(bytes: &Option<Vec<u8>>, serializer: S) -> Result<S::Ok, S::Error> {
+			match bytes {
+				Some(bytes) => serializer.serialize_str(&STANDARD.encode(bytes)),
+				None => serializer.serialize_none(),
+			}
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<Vec<u8>>, D::Error> {
+			let s = Option::<String>::deserialize(deserializer)?;
+			s.map(|s| STANDARD.decode(s).map_err(serde::de::Error::custom)).transpose()
+		}
+	}
+}
+
@@ -0,0 +1,78 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+var (
+	order = &types.Struct{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/service.Order",
+		Fields: map[string]types.StructField{
+			"ID": {Type: &types.String{}},
+		},
+	}
+
+	goError = &types.Trait{Name: types.ErrorTraitName}
+
+	logger = &types.Trait{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/service.Logger",
+		Methods: []*types.Function{
+			{
+				Name:     "Log",
+				IsMethod: true,
+				Params:   []types.FunctionParam{{Name: "msg", Type: &types.String{}}},
+			},
+		},
+	}
+
+	// Data13 - 13.rs
+	Data13 = map[string]types.Type{
+		"github.com/drewstone/go2rs/pkg/parser/testdata/service.Order":  order,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/service.Logger": logger,
+		"github.com/drewstone/go2rs/pkg/parser/testdata/service.NotFound": &types.Trait{
+			Name:        "github.com/drewstone/go2rs/pkg/parser/testdata/service.NotFound",
+			Supertraits: []*types.Trait{goError},
+			Methods: []*types.Function{
+				{Name: "Key", IsMethod: true, Returns: &types.String{}},
+			},
+		},
+		"github.com/drewstone/go2rs/pkg/parser/testdata/service.OrderService": &types.Trait{
+			Name:        "github.com/drewstone/go2rs/pkg/parser/testdata/service.OrderService",
+			Supertraits: []*types.Trait{logger},
+			Methods: []*types.Function{
+				{
+					Name:     "Get",
+					IsMethod: true,
+					Params:   []types.FunctionParam{{Name: "id", Type: &types.String{}}},
+					Returns:  &types.Result{Ok: order, Err: goError},
+				},
+				{
+					Name:     "Search",
+					IsMethod: true,
+					IsAsync:  true,
+					Params: []types.FunctionParam{
+						{
+							Name: "filter",
+							Type: &types.Struct{
+								Fields: map[string]types.StructField{
+									"Query": {Type: &types.String{}},
+									"Limit": {Type: &types.Number{IsSigned: true, BitSize: 32}},
+								},
+							},
+						},
+						{Name: "type", Type: &types.String{}},
+						{Name: "log", Type: logger},
+					},
+					Returns: &types.Result{
+						Ok:  &types.Tuple{Types: []types.Type{&types.Vec{Inner: order}, &types.Boolean{}}},
+						Err: goError,
+					},
+				},
+				{
+					Name:     "Delete",
+					IsMethod: true,
+					Params:   []types.FunctionParam{{Name: "id", Type: &types.String{}}},
+					Returns:  &types.Result{Ok: &types.Unit{}, Err: goError},
+				},
+			},
+		},
+	}
+)
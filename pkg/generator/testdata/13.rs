@@ -0,0 +1,32 @@
+use serde::{Serialize, Deserialize};
+
+pub trait Logger {
+	fn log(&self, msg: String);
+}
+
+pub trait NotFound: std::error::Error {
+	fn key(&self) -> String;
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Order {
+	#[serde(rename = "ID")]
+	pub i_d: String,
+}
+
+pub trait OrderService: Logger {
+	fn get(&self, id: String) -> Result<Order, Error>;
+	async fn search(&self, filter: SearchFilter, r#type: String, log: Box<dyn Logger>) -> Result<(Vec<Order>, bool), Error>;
+	fn delete(&self, id: String) -> Result<(), Error>;
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct SearchFilter {
+	#[serde(rename = "Limit")]
+	pub limit: i32,
+	#[serde(rename = "Query")]
+	pub query: String,
+}
+
@@ -0,0 +1,27 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+// Data14 - 14.rs
+var Data14 = map[string]types.Type{
+	"github.com/drewstone/go2rs/pkg/parser/testdata/ext.Extension": &types.Struct{
+		Name: "github.com/drewstone/go2rs/pkg/parser/testdata/ext.Extension",
+		Fields: map[string]types.StructField{
+			"Data":     {Type: &types.Any{}},
+			"Metadata": {Type: &types.Any{}, Optional: true},
+			"Extra": {
+				Type: &types.Nullable{
+					Inner: &types.Map{Key: &types.String{}, Value: &types.Any{}},
+				},
+			},
+			"Items": {
+				Type: &types.Nullable{
+					Inner: &types.Vec{Inner: &types.Any{}},
+				},
+			},
+			"ByIndex": {
+				Type: &types.Map{Key: &types.Number{IsSigned: true, BitSize: 64}, Value: &types.Any{}},
+			},
+		},
+	},
+}
@@ -0,0 +1,20 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+use serde_json::Value;
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Extension {
+	#[serde(rename = "ByIndex")]
+	pub by_index: HashMap<i64, Value>,
+	#[serde(rename = "Data")]
+	pub data: Value,
+	#[serde(rename = "Extra")]
+	pub extra: Option<serde_json::Map<String, Value>>,
+	#[serde(rename = "Items")]
+	pub items: Option<Vec<Value>>,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Metadata")]
+	pub metadata: Option<Value>,
+}
+
@@ -0,0 +1,60 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+const graphPkg = "github.com/drewstone/go2rs/pkg/parser/testdata/graph"
+
+// Data15 - 15.rs
+var Data15 = map[string]types.Type{
+	graphPkg + ".Parent": &types.Struct{
+		Name: graphPkg + ".Parent",
+		Fields: map[string]types.StructField{
+			"Siblings": {
+				Type: &types.Vec{Inner: &types.Struct{Name: graphPkg + ".Parent"}},
+			},
+		},
+	},
+	graphPkg + ".Child": &types.Struct{
+		Name:   graphPkg + ".Child",
+		Fields: map[string]types.StructField{},
+	},
+	graphPkg + ".Expr": &types.Enum{
+		Name: graphPkg + ".Expr",
+		Variants: map[string]types.EnumVariant{
+			"Literal": {Type: &types.Number{IsFloat: true, BitSize: 64}, FieldIndex: 0},
+			"Binary":  {Type: &types.Struct{Name: graphPkg + ".BinaryOp"}, FieldIndex: 1},
+		},
+	},
+	graphPkg + ".BinaryOp": &types.Struct{
+		Name: graphPkg + ".BinaryOp",
+		Fields: map[string]types.StructField{
+			"Left":     {Type: &types.Enum{Name: graphPkg + ".Expr"}},
+			"Right":    {Type: &types.Enum{Name: graphPkg + ".Expr"}},
+			"Operands": {Type: &types.Map{Key: &types.String{}, Value: &types.Enum{Name: graphPkg + ".Expr"}}},
+		},
+	},
+	graphPkg + ".Cons": &types.Tuple{
+		Name: graphPkg + ".Cons",
+		Types: []types.Type{
+			&types.Number{IsSigned: true, BitSize: 64},
+			&types.Nullable{Inner: &types.Tuple{Name: graphPkg + ".Cons"}},
+		},
+	},
+}
+
+func init() {
+	parent := Data15[graphPkg+".Parent"].(*types.Struct)
+	child := Data15[graphPkg+".Child"].(*types.Struct)
+
+	// Parent and Child refer to each other by value, and Child also through an anonymous struct
+	parent.Fields["Child"] = types.StructField{Type: child}
+	child.Fields["Parent"] = types.StructField{Type: parent, Optional: true}
+	child.Fields["Meta"] = types.StructField{
+		Type: &types.Struct{
+			Fields: map[string]types.StructField{
+				"Owner": {Type: &types.Nullable{Inner: child}},
+				"Path":  {Type: &types.Array{Inner: parent, Size: 2}},
+			},
+		},
+	}
+}
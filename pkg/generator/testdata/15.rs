@@ -0,0 +1,51 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub enum Expr {
+	Literal(f64),
+	Binary(Box<BinaryOp>),
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct BinaryOp {
+	#[serde(rename = "Left")]
+	pub left: Expr,
+	#[serde(rename = "Operands")]
+	pub operands: HashMap<String, Expr>,
+	#[serde(rename = "Right")]
+	pub right: Expr,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Child {
+	#[serde(rename = "Meta")]
+	pub meta: Meta,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Parent")]
+	pub parent: Option<Parent>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Cons(pub i64, pub Option<Box<Cons>>);
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Meta {
+	#[serde(rename = "Owner")]
+	pub owner: Option<Box<Child>>,
+	#[serde(rename = "Path")]
+	pub path: [Parent; 2],
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Parent {
+	#[serde(rename = "Child")]
+	pub child: Box<Child>,
+	#[serde(rename = "Siblings")]
+	pub siblings: Vec<Parent>,
+}
+
@@ -0,0 +1,56 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+const promotePkg = "github.com/drewstone/go2rs/pkg/parser/testdata/promote"
+
+var (
+	audit16 = &types.Struct{
+		Name: promotePkg + ".Audit",
+		Fields: map[string]types.StructField{
+			"created_by": {RawName: "CreatedBy", RawTag: `json:"created_by"`, Type: &types.String{}},
+			"id":         {RawName: "ID", RawTag: `json:"id"`, Type: &types.String{}},
+			"Note":       {RawName: "Comment", RawTag: `json:"Note"`, Type: &types.Nullable{Inner: &types.String{}}},
+		},
+	}
+	meta16 = &types.Struct{
+		Name: promotePkg + ".Meta",
+		Fields: map[string]types.StructField{
+			"id":   {RawName: "ID", RawTag: `json:"id"`, Type: &types.String{}},
+			"Note": {RawName: "Note", Type: &types.String{}},
+		},
+	}
+	owner16 = &types.Struct{
+		Name: promotePkg + ".Owner",
+		Fields: map[string]types.StructField{
+			"name":  {RawName: "Name", RawTag: `json:"name"`, Type: &types.String{}},
+			"Email": {RawName: "Email", Type: &types.String{}},
+			"Meta":  {RawName: "Meta", Type: meta16, Embedded: true},
+		},
+	}
+	labels16 = &types.Struct{
+		Name: promotePkg + ".Labels",
+		Fields: map[string]types.StructField{
+			"labels": {RawName: "Labels", RawTag: `json:"labels"`, Type: &types.Map{Key: &types.String{}, Value: &types.String{}}},
+		},
+	}
+)
+
+// Data16 - 16.rs
+var Data16 = map[string]types.Type{
+	promotePkg + ".Audit":  audit16,
+	promotePkg + ".Meta":   meta16,
+	promotePkg + ".Owner":  owner16,
+	promotePkg + ".Labels": labels16,
+	promotePkg + ".Record": &types.Struct{
+		Name: promotePkg + ".Record",
+		Fields: map[string]types.StructField{
+			// Shadows Owner.name, which is deeper
+			"name":   {RawName: "Name", RawTag: `json:"name"`, Type: &types.String{}},
+			"Audit":  {RawName: "Audit", Type: &types.Nullable{Inner: audit16}, Embedded: true},
+			"Meta":   {RawName: "Meta", Type: meta16, Embedded: true},
+			"Owner":  {RawName: "Owner", Type: owner16, Embedded: true},
+			"Labels": {RawName: "Labels", Type: labels16, Embedded: true},
+		},
+	},
+}
@@ -0,0 +1,58 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Audit {
+	#[serde(rename = "Note")]
+	pub note: Option<String>,
+	#[serde(rename = "created_by")]
+	pub created_by: String,
+	#[serde(rename = "id")]
+	pub id: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Labels {
+	#[serde(rename = "labels")]
+	pub labels: HashMap<String, String>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Meta {
+	#[serde(rename = "Note")]
+	pub note: String,
+	#[serde(rename = "id")]
+	pub id: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Owner {
+	#[serde(rename = "Email")]
+	pub email: String,
+	#[serde(flatten)]
+	pub meta: Meta,
+	#[serde(rename = "name")]
+	pub name: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Record {
+	#[serde(rename = "Email")]
+	pub email: String,
+	#[serde(flatten)]
+	pub labels: Labels,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "Note")]
+	pub note: Option<String>,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "created_by")]
+	pub created_by: Option<String>,
+	#[serde(rename = "name")]
+	pub name: String,
+}
+
@@ -0,0 +1,96 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+const genericsPkg = "github.com/drewstone/go2rs/pkg/parser/testdata/generics"
+
+// Data17 - 17.rs
+var Data17 = map[string]types.Type{
+	genericsPkg + ".Order": &types.Struct{
+		Name: genericsPkg + ".Order",
+		Fields: map[string]types.StructField{
+			"id": {Type: &types.String{}},
+		},
+	},
+	genericsPkg + ".Page": &types.Struct{
+		Name:       genericsPkg + ".Page",
+		TypeParams: []*types.TypeParam{{Name: "T", Constraint: "any"}},
+		Fields: map[string]types.StructField{
+			"items": {Type: &types.Nullable{Inner: &types.Vec{Inner: &types.TypeParam{Name: "T"}}}},
+			"next":  {Type: &types.String{}},
+		},
+	},
+	genericsPkg + ".Index": &types.Struct{
+		Name: genericsPkg + ".Index",
+		TypeParams: []*types.TypeParam{
+			{Name: "K", Constraint: "comparable", Comparable: true},
+			{Name: "V", Constraint: "any"},
+		},
+		Fields: map[string]types.StructField{
+			"entries": {Type: &types.Map{Key: &types.TypeParam{Name: "K"}, Value: &types.TypeParam{Name: "V"}}},
+		},
+	},
+	genericsPkg + ".Holder": &types.Struct{
+		Name:       genericsPkg + ".Holder",
+		TypeParams: []*types.TypeParam{{Name: "T", Constraint: "any"}},
+		Fields: map[string]types.StructField{
+			"item": {Type: &types.TypeParam{Name: "T"}},
+		},
+	},
+	genericsPkg + ".Tree": &types.Struct{
+		Name:       genericsPkg + ".Tree",
+		TypeParams: []*types.TypeParam{{Name: "T", Constraint: "any"}},
+		Fields: map[string]types.StructField{
+			"value": {Type: &types.TypeParam{Name: "T"}},
+			"children": {
+				Type: &types.Vec{Inner: &types.Struct{
+					Name:     genericsPkg + ".Tree",
+					TypeArgs: []types.Type{&types.TypeParam{Name: "T"}},
+				}},
+			},
+		},
+	},
+	genericsPkg + ".Node": &types.Struct{
+		Name: genericsPkg + ".Node",
+		Fields: map[string]types.StructField{
+			// Holder stores Node by value, so Node is boxed inside it
+			"child": {
+				Optional: true,
+				Type: &types.Struct{
+					Name:     genericsPkg + ".Holder",
+					TypeArgs: []types.Type{&types.Struct{Name: genericsPkg + ".Node"}},
+				},
+			},
+			// Page only stores Node in a Vec
+			"page": {
+				Type: &types.Struct{
+					Name:     genericsPkg + ".Page",
+					TypeArgs: []types.Type{&types.Struct{Name: genericsPkg + ".Node"}},
+				},
+			},
+		},
+	},
+	genericsPkg + ".Orders": &types.Struct{
+		Name: genericsPkg + ".Orders",
+		Fields: map[string]types.StructField{
+			"page": {
+				Type: &types.Struct{
+					Name:     genericsPkg + ".Page",
+					TypeArgs: []types.Type{&types.Struct{Name: genericsPkg + ".Order"}},
+				},
+			},
+			"index": {
+				Type: &types.Struct{
+					Name:     genericsPkg + ".Index",
+					TypeArgs: []types.Type{&types.String{}, &types.Date{}},
+				},
+			},
+			"tree": {
+				Type: &types.Struct{
+					Name:     genericsPkg + ".Tree",
+					TypeArgs: []types.Type{&types.Number{IsSigned: true, BitSize: 64}},
+				},
+			},
+		},
+	},
+}
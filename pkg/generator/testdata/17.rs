@@ -0,0 +1,64 @@
+use serde::{Serialize, Deserialize};
+use std::collections::HashMap;
+use chrono::{DateTime, Utc};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Holder<T> {
+	#[serde(rename = "item")]
+	pub item: T,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Index<K: Eq + std::hash::Hash, V> {
+	#[serde(rename = "entries")]
+	pub entries: HashMap<K, V>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Node {
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "child")]
+	pub child: Option<Holder<Box<Node>>>,
+	#[serde(rename = "page")]
+	pub page: Page<Node>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Order {
+	#[serde(rename = "id")]
+	pub id: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Orders {
+	#[serde(rename = "index")]
+	pub index: Index<String, DateTime<Utc>>,
+	#[serde(rename = "page")]
+	pub page: Page<Order>,
+	#[serde(rename = "tree")]
+	pub tree: Tree<i64>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Page<T> {
+	#[serde(rename = "items")]
+	pub items: Option<Vec<T>>,
+	#[serde(rename = "next")]
+	pub next: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Tree<T> {
+	#[serde(rename = "children")]
+	pub children: Vec<Tree<T>>,
+	#[serde(rename = "value")]
+	pub value: T,
+}
+
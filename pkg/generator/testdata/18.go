@@ -0,0 +1,60 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+const wirePkg = "github.com/drewstone/go2rs/pkg/parser/testdata/wire"
+
+// Data18 - 18.rs
+var Data18 = map[string]types.Type{
+	wirePkg + ".Settings": &types.Struct{
+		Name: wirePkg + ".Settings",
+		Fields: map[string]types.StructField{
+			"timeout": {
+				Type: &types.Number{Common: types.Common{GoType: "time.Duration"}, IsSigned: true, BitSize: 64},
+			},
+			"retry": {
+				Type:     &types.Number{Common: types.Common{GoType: "time.Duration"}, IsSigned: true, BitSize: 64},
+				Optional: true,
+			},
+			"amount": {Type: &types.String{Common: types.Common{GoType: "encoding/json.Number"}}},
+			"balance": {
+				Type: &types.Nullable{Inner: &types.Struct{Common: types.Common{GoType: "math/big.Int"}}},
+			},
+			"address": {
+				Type: &types.Nullable{
+					Common: types.Common{GoType: "net.IP"},
+					Inner:  &types.Vec{Inner: &types.Number{BitSize: 8}},
+				},
+			},
+			"peer":     {Type: &types.Struct{Common: types.Common{GoType: "net/netip.Addr"}}},
+			"endpoint": {Type: &types.Struct{Common: types.Common{GoType: "net/url.URL"}}},
+			"callback": {
+				Type:     &types.Struct{Common: types.Common{GoType: "net/url.URL"}},
+				Optional: true,
+			},
+			"filter": {
+				Type: &types.Nullable{Inner: &types.Struct{Common: types.Common{GoType: "regexp.Regexp"}}},
+			},
+		},
+	},
+	wirePkg + ".Event": &types.Struct{
+		Name: wirePkg + ".Event",
+		Fields: map[string]types.StructField{
+			"settings": {Type: &types.Struct{Name: wirePkg + ".Settings"}},
+			"payload": {
+				Type: &types.Nullable{
+					Common: types.Common{GoType: "encoding/json.RawMessage"},
+					Inner:  &types.Vec{Inner: &types.Number{BitSize: 8}},
+				},
+			},
+		},
+	},
+	wirePkg + ".Batch": &types.Struct{
+		Name: wirePkg + ".Batch",
+		Fields: map[string]types.StructField{
+			"events": {
+				Type: &types.Nullable{Inner: &types.Vec{Inner: &types.Struct{Name: wirePkg + ".Event"}}},
+			},
+		},
+	},
+}
@@ -0,0 +1,211 @@
+use serde::{Serialize, Deserialize};
+use num_bigint::BigInt;
+use serde_json::value::RawValue;
+use std::net::IpAddr;
+use std::time::Duration;
+use url::Url;
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Batch {
+	#[serde(rename = "events")]
+	pub events: Option<Vec<Event>>,
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Event {
+	#[serde(rename = "payload")]
+	pub payload: Box<RawValue>,
+	#[serde(rename = "settings")]
+	pub settings: Settings,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Settings {
+	#[serde(rename = "address")]
+	pub address: IpAddr,
+	#[serde(rename = "amount")]
+	pub amount: serde_json::Number,
+	#[serde(rename = "balance")]
+	#[serde(with = "go_big_int::option")]
+	pub balance: Option<BigInt>,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "callback")]
+	#[serde(default, with = "go_url::option")]
+	pub callback: Option<Url>,
+	#[serde(rename = "endpoint")]
+	#[serde(with = "go_url")]
+	pub endpoint: Url,
+	#[serde(rename = "filter")]
+	pub filter: Option<String>,
+	#[serde(rename = "peer")]
+	pub peer: IpAddr,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "retry")]
+	#[serde(default, with = "go_duration::option")]
+	pub retry: Option<Duration>,
+	#[serde(rename = "timeout")]
+	#[serde(with = "go_duration")]
+	pub timeout: Duration,
+}
+
+/// Serializes BigInt as a bare JSON number like Go's big.Int, which needs the raw_value feature of serde_json
+pub mod go_big_int {
+	use num_bigint::BigInt;
+	use serde::{Deserialize, Deserializer, Serialize, Serializer};
+	use serde_json::value::RawValue;
+
+	pub fn serialize<S: Serializer>(n: &BigInt, serializer: S) -> Result<S::Ok, S::Error> {
+		RawValue::from_string(n.to_string()).map_err(serde::ser::Error::custom)?.serialize(serializer)
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<BigInt, D::Error> {
+		let raw = Box::<RawValue>::deserialize(deserializer)?;
+		raw.get().parse().map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<BigInt> as a bare JSON number or null
+	pub mod option {
+		use num_bigint::BigInt;
+		use serde::{Deserialize, Deserializer, Serializer};
+		use serde_json::value::RawValue;
+
+		pub fn serialize<S: Serializer>(n: &Option<BigInt>, serializer: S) -> Result<S::Ok, S::Error> {
+			match n {
+				Some(n) => super::serialize(n, serializer),
+				None => serializer.serialize_none(),
+			}
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<BigInt>, D::Error> {
+			let raw = Option::<Box<RawValue>>::deserialize(deserializer)?;
+			raw.map(|raw| raw.get().parse().map_err(serde::de::Error::custom)).transpose()
+		}
+	}
+}
+
+/// Serializes Duration as integer nanoseconds like Go's time.Duration
+pub mod go_duration {
+	use serde::{Deserialize, Deserializer, Serializer};
+	use std::time::Duration;
+
+	pub fn serialize<S: Serializer>(duration: &Duration, serializer: S) -> Result<S::Ok, S::Error> {
+		let nanos = i64::try_from(duration.as_nanos()).map_err(serde::ser::Error::custom)?;
+		serializer.serialize_i64(nanos)
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Duration, D::Error> {
+		let nanos = i64::deserialize(deserializer)?;
+		u64::try_from(nanos).map(Duration::from_nanos).map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<Duration> as integer nanoseconds or null
+	pub mod option {
+		use serde::{Deserialize, Deserializer, Serializer};
+		use std::time::Duration;
+
+		pub fn serialize<S: Serializer>(duration: &Option<Duration>, serializer: S) -> Result<S::Ok, S::Error> {
+			match duration {
+				Some(duration) => super::serialize(duration, serializer),
+				None => serializer.serialize_none(),
+			}
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<Duration>, D::Error> {
+			let nanos = Option::<i64>::deserialize(deserializer)?;
+			nanos.map(|nanos| u64::try_from(nanos).map(Duration::from_nanos).map_err(serde::de::Error::custom)).transpose()
+		}
+	}
+}
+
+/// Serializes Url as the object Go's encoding/json makes of url.URL, which only holds absolute URLs in Rust
+pub mod go_url {
+	use serde::{Deserialize, Deserializer, Serialize, Serializer};
+	use url::Url;
+
+	#[derive(Default, Serialize, Deserialize)]
+	#[serde(rename_all = "PascalCase", default)]
+	struct GoUrl {
+		scheme: String,
+		opaque: String,
+		user: Option<Userinfo>,
+		host: String,
+		path: String,
+		raw_path: String,
+		omit_host: bool,
+		force_query: bool,
+		raw_query: String,
+		fragment: String,
+		raw_fragment: String,
+	}
+
+	/// Go's url.Userinfo has no exported fields, so only whether it is set is serialized
+	#[derive(Default, Serialize, Deserialize)]
+	struct Userinfo {}
+
+	fn to_go(url: &Url) -> GoUrl {
+		let raw_query = url.query().unwrap_or_default().to_string();
+		let fragment = url.fragment().unwrap_or_default().to_string();
+		if url.cannot_be_a_base() {
+			return GoUrl { scheme: url.scheme().to_string(), opaque: url.path().to_string(), raw_query, fragment, ..GoUrl::default() };
+		}
+
+		let mut host = url.host_str().unwrap_or_default().to_string();
+		if let Some(port) = url.port() {
+			host = format!("{host}:{port}");
+		}
+		GoUrl {
+			scheme: url.scheme().to_string(),
+			user: (!url.username().is_empty() || url.password().is_some()).then(Userinfo::default),
+			host,
+			path: url.path().to_string(),
+			raw_query,
+			fragment,
+			..GoUrl::default()
+		}
+	}
+
+	fn from_go(url: GoUrl) -> Result<Url, url::ParseError> {
+		let mut s = if url.opaque.is_empty() {
+			let path = if url.raw_path.is_empty() { &url.path } else { &url.raw_path };
+			format!("{}://{}{}", url.scheme, url.host, path)
+		} else {
+			format!("{}:{}", url.scheme, url.opaque)
+		};
+		if url.force_query || !url.raw_query.is_empty() {
+			s.push('?');
+			s.push_str(&url.raw_query);
+		}
+		if !url.fragment.is_empty() {
+			s.push('#');
+			s.push_str(&url.fragment);
+		}
+		Url::parse(&s)
+	}
+
+	pub fn serialize<S: Serializer>(url: &Url, serializer: S) -> Result<S::Ok, S::Error> {
+		to_go(url).serialize(serializer)
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Url, D::Error> {
+		from_go(GoUrl::deserialize(deserializer)?).map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<Url> as the object of url.URL or null
+	pub mod option {
+		use serde::{Deserialize, Deserializer, Serialize, Serializer};
+		use url::Url;
+
+		pub fn serialize<S: Serializer>(url: &Option<Url>, serializer: S) -> Result<S::Ok, S::Error> {
+			url.as_ref().map(super::to_go).serialize(serializer)
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<Url>, D::Error> {
+			let url = Option::<super::GoUrl>::deserialize(deserializer)?;
+			url.map(super::from_go).transpose().map_err(serde::de::Error::custom)
+		}
+	}
+}
+
@@ -0,0 +1,33 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+const embedPkg = "github.com/drewstone/go2rs/pkg/parser/testdata/embed"
+
+// Data19 - 19.rs
+var Data19 = map[string]types.Type{
+	embedPkg + ".Embedded": &types.Struct{
+		Name: embedPkg + ".Embedded",
+		Fields: map[string]types.StructField{
+			"foo": {
+				Optional: true,
+				Type:     &types.Number{},
+			},
+		},
+	},
+	embedPkg + ".Data": &types.Struct{
+		Name: embedPkg + ".Data",
+		Fields: map[string]types.StructField{
+			"A": {
+				Type: &types.Number{},
+			},
+			// Refers to the registered struct by its name only
+			"Embedded": {
+				Embedded: true,
+				Type: &types.Struct{
+					Name: embedPkg + ".Embedded",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,19 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Data {
+	#[serde(rename = "A")]
+	pub a: i64,
+	#[serde(flatten)]
+	pub embedded: Embedded,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Embedded {
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "foo")]
+	pub foo: Option<i64>,
+}
+
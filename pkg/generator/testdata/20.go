@@ -0,0 +1,19 @@
+package testdata
+
+import types "github.com/drewstone/go2rs/pkg/types"
+
+const namingPkg = "github.com/drewstone/go2rs/pkg/parser/testdata/naming"
+
+// Data20 - 20.rs
+var Data20 = map[string]types.Type{
+	namingPkg + ".Webhook": &types.Struct{
+		Name: namingPkg + ".Webhook",
+		Fields: map[string]types.StructField{
+			// Lowercase json keys which are already valid Rust field names
+			"endpoint":    {RawName: "Endpoint", RawTag: `json:"endpoint"`, Type: &types.String{}},
+			"retry_count": {RawName: "RetryCount", RawTag: `json:"retry_count,omitempty"`, Type: &types.Number{}, Optional: true},
+			"Name":        {RawName: "Name", RawTag: `json:"Name"`, Type: &types.String{}},
+			"Secret":      {RawName: "Secret", Type: &types.String{}},
+		},
+	},
+}
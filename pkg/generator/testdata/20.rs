@@ -0,0 +1,16 @@
+use serde::{Serialize, Deserialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "PascalCase")]
+pub struct Webhook {
+	#[serde(rename = "Name")]
+	pub name: String,
+	#[serde(rename = "Secret")]
+	pub secret: String,
+	#[serde(rename = "endpoint")]
+	pub endpoint: String,
+	#[serde(skip_serializing_if = "Option::is_none")]
+	#[serde(rename = "retry_count")]
+	pub retry_count: Option<i64>,
+}
+
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// errorType returns the Rust type of Go's error in Result
+func (g *Generator) errorType() string {
+	if g.ErrorType != "" {
+		return g.ErrorType
+	}
+
+	return "Error"
+}
+
+// traitRef returns how the trait t is referred to from the module being generated
+func (g *Generator) traitRef(t *rstypes.Trait) string {
+	if t.IsError() {
+		return "std::error::Error"
+	}
+
+	return g.qualify(splitKey(g.structKey(t.Name)))
+}
+
+// snakeIdent returns the Rust identifier of a Go parameter or method name in snake_case
+func snakeIdent(name string) string {
+	name = toSnakeCase(name)
+
+	switch {
+	case name == "self" || name == "crate" || name == "super":
+		return name + "_"
+	case rustKeywords[name]:
+		return "r#" + name
+	}
+
+	return name
+}
+
+// signatureType returns the Rust type of a parameter or a result of a method
+func (g *Generator) signatureType(t rstypes.Type, name string) string {
+	prev := g.signature
+	g.signature = true
+	defer func() { g.signature = prev }()
+
+	return g.GenerateTypeSimple(t, name)
+}
+
+// functionType returns the Rust type of a Go func value in a method signature
+func (g *Generator) functionType(fn *rstypes.Function, name string) string {
+	params := make([]string, 0, len(fn.Params))
+	for _, p := range fn.Params {
+		params = append(params, g.signatureType(p.Type, name))
+	}
+
+	ret := ""
+	if fn.Returns != nil {
+		if _, unit := fn.Returns.(*rstypes.Unit); !unit {
+			ret = " -> " + g.signatureType(fn.Returns, name)
+		}
+	}
+
+	return fmt.Sprintf("Box<dyn Fn(%s)%s + Send + Sync>", strings.Join(params, ", "), ret)
+}
+
+// generateTrait generates a trait with the methods of a Go interface, which take &self
+func (g *Generator) generateTrait(t *rstypes.Trait) string {
+	buf := bytes.NewBuffer(nil)
+
+	var name string
+	g.currentModule, name = splitKey(g.structKey(t.Name))
+
+	supertraits := make([]string, 0, len(t.Supertraits))
+	for _, s := range t.Supertraits {
+		supertraits = append(supertraits, g.traitRef(s))
+	}
+
+	buf.WriteString("pub trait " + name)
+	if len(supertraits) != 0 {
+		buf.WriteString(": " + strings.Join(supertraits, " + "))
+	}
+	buf.WriteString(" {\n")
+
+	for _, m := range t.Methods {
+		g.field = &fieldContext{
+			goType:   goTypeName(t),
+			name:     m.Name,
+			position: m.Position,
+			kind:     "method",
+		}
+
+		params := make([]string, 0, len(m.Params)+1)
+		if m.IsMethod {
+			params = append(params, "&self")
+		}
+		for _, p := range m.Params {
+			params = append(params, fmt.Sprintf("%s: %s", snakeIdent(p.Name), g.signatureType(p.Type, m.Name+identifier(p.Name))))
+		}
+
+		ret := ""
+		if m.Returns != nil {
+			if _, unit := m.Returns.(*rstypes.Unit); !unit {
+				ret = " -> " + g.signatureType(m.Returns, m.Name+"Result")
+			}
+		}
+		g.field = nil
+
+		async := ""
+		if m.IsAsync || g.AsyncTraits {
+			async = "async "
+		}
+
+		buf.WriteString(fmt.Sprintf("\t%sfn %s(%s)%s;\n", async, snakeIdent(m.Name), strings.Join(params, ", "), ret))
+	}
+
+	buf.WriteString("}")
+	return buf.String()
+}
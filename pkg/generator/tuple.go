@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// tupleElement returns the name of anonymous types in the i-th element of a tuple named name
+func tupleElement(name string, i int) string {
+	return fmt.Sprintf("%s%d", name, i)
+}
+
+// tupleName returns the Rust name of a named tuple, which anonymous types in its elements are named after
+func (g *Generator) tupleName(t *rstypes.Tuple) string {
+	_, name := splitKey(g.structKey(t.Name))
+	return name
+}
+
+// tupleTypes returns the Rust types of the elements of t
+func (g *Generator) tupleTypes(t *rstypes.Tuple, name string, typeStack []rstypes.Type) []string {
+	types := make([]string, 0, len(t.Types))
+	for i, elem := range t.Types {
+		types = append(types, g.GenerateTypeSimpleWithContext(elem, tupleElement(name, i), typeStack))
+	}
+
+	return types
+}
+
+// tupleType returns the Rust type of an anonymous tuple
+func (g *Generator) tupleType(t *rstypes.Tuple, fieldName string, typeStack []rstypes.Type) string {
+	types := g.tupleTypes(t, fieldName, typeStack)
+
+	// (T) is not a tuple in Rust
+	if len(types) == 1 {
+		return fmt.Sprintf("(%s,)", types[0])
+	}
+
+	return "(" + strings.Join(types, ", ") + ")"
+}
+
+// generateTupleStruct generates a tuple struct from a named tuple, which is serialized as an array
+func (g *Generator) generateTupleStruct(t *rstypes.Tuple) string {
+	buf := bytes.NewBuffer(nil)
+
+	var name string
+	g.currentModule, name = splitKey(g.structKey(t.Name))
+
+	types := g.tupleTypes(t, name, nil)
+	elems := t.Types
+
+	// serde serializes a struct with a single field as the field itself, so the field is wrapped in a tuple
+	if len(types) == 1 {
+		types[0] = fmt.Sprintf("(%s,)", types[0])
+		elems = []rstypes.Type{&rstypes.Tuple{Types: t.Types}}
+	}
+
+	serdeAs := false
+	attrs := make([]string, len(elems))
+	for i, elem := range elems {
+		if as := g.serdeAs(elem, false); as != "" {
+			attrs[i] = fmt.Sprintf("#[serde_as(as = %q)] ", as)
+			serdeAs = true
+		}
+		if with := g.adapterField(elem, false); with != "" {
+			attrs[i] += fmt.Sprintf("#[serde(with = %q)] ", with)
+		}
+	}
+	if serdeAs {
+		buf.WriteString("#[serde_as]\n")
+	}
+
+	buf.WriteString(g.derive("Debug", "Clone", "PartialEq", "Serialize", "Deserialize"))
+	buf.WriteString(fmt.Sprintf("pub struct %s(", name))
+	for i, typ := range types {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(attrs[i] + "pub " + typ)
+	}
+	buf.WriteString(");")
+
+	return buf.String()
+}
@@ -0,0 +1,383 @@
+package generator
+
+import (
+	"sort"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// wireType is a built-in mapping of a Go standard library type onto the Rust type with the same encoding
+type wireType struct {
+	rustType string
+	imports  []string
+	// adapter is the module generated into the root module when the Rust type is serialized differently by serde
+	adapter string
+	// noPartialEq is set when the Rust type does not implement PartialEq
+	noPartialEq bool
+}
+
+// wireTypes are the standard library types encoding/json does not encode like their underlying types.
+// Overrides take precedence over them.
+var wireTypes = map[string]wireType{
+	"time.Duration": {
+		rustType: "Duration",
+		imports:  []string{"std::time::Duration"},
+		adapter:  durationModule,
+	},
+	"encoding/json.RawMessage": {
+		rustType:    "Box<RawValue>",
+		imports:     []string{"serde_json::value::RawValue"},
+		noPartialEq: true,
+	},
+	// json.RawMessage is an alias of jsontext.Value with GOEXPERIMENT=jsonv2
+	"encoding/json/jsontext.Value": {
+		rustType:    "Box<RawValue>",
+		imports:     []string{"serde_json::value::RawValue"},
+		noPartialEq: true,
+	},
+	"encoding/json.Number": {
+		rustType: "serde_json::Number",
+	},
+	"math/big.Int": {
+		rustType: "BigInt",
+		imports:  []string{"num_bigint::BigInt"},
+		adapter:  bigIntModule,
+	},
+	"net.IP": {
+		rustType: "IpAddr",
+		imports:  []string{"std::net::IpAddr"},
+	},
+	"net/netip.Addr": {
+		rustType: "IpAddr",
+		imports:  []string{"std::net::IpAddr"},
+	},
+	"net/url.URL": {
+		rustType: "Url",
+		imports:  []string{"url::Url"},
+		adapter:  urlModule,
+	},
+	// regex::Regexp implements neither serde nor PartialEq, so the pattern is kept as it is marshaled
+	"regexp.Regexp": {
+		rustType: "String",
+	},
+}
+
+const (
+	durationModule = "go_duration"
+	bigIntModule   = "go_big_int"
+	urlModule      = "go_url"
+)
+
+// wireAdapters are the serde adapters of the wire types, keyed by their module names
+var wireAdapters = map[string]string{
+	durationModule: `/// Serializes Duration as integer nanoseconds like Go's time.Duration
+pub mod go_duration {
+	use serde::{Deserialize, Deserializer, Serializer};
+	use std::time::Duration;
+
+	pub fn serialize<S: Serializer>(duration: &Duration, serializer: S) -> Result<S::Ok, S::Error> {
+		let nanos = i64::try_from(duration.as_nanos()).map_err(serde::ser::Error::custom)?;
+		serializer.serialize_i64(nanos)
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Duration, D::Error> {
+		let nanos = i64::deserialize(deserializer)?;
+		u64::try_from(nanos).map(Duration::from_nanos).map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<Duration> as integer nanoseconds or null
+	pub mod option {
+		use serde::{Deserialize, Deserializer, Serializer};
+		use std::time::Duration;
+
+		pub fn serialize<S: Serializer>(duration: &Option<Duration>, serializer: S) -> Result<S::Ok, S::Error> {
+			match duration {
+				Some(duration) => super::serialize(duration, serializer),
+				None => serializer.serialize_none(),
+			}
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<Duration>, D::Error> {
+			let nanos = Option::<i64>::deserialize(deserializer)?;
+			nanos.map(|nanos| u64::try_from(nanos).map(Duration::from_nanos).map_err(serde::de::Error::custom)).transpose()
+		}
+	}
+}`,
+	bigIntModule: `/// Serializes BigInt as a bare JSON number like Go's big.Int, which needs the raw_value feature of serde_json
+pub mod go_big_int {
+	use num_bigint::BigInt;
+	use serde::{Deserialize, Deserializer, Serialize, Serializer};
+	use serde_json::value::RawValue;
+
+	pub fn serialize<S: Serializer>(n: &BigInt, serializer: S) -> Result<S::Ok, S::Error> {
+		RawValue::from_string(n.to_string()).map_err(serde::ser::Error::custom)?.serialize(serializer)
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<BigInt, D::Error> {
+		let raw = Box::<RawValue>::deserialize(deserializer)?;
+		raw.get().parse().map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<BigInt> as a bare JSON number or null
+	pub mod option {
+		use num_bigint::BigInt;
+		use serde::{Deserialize, Deserializer, Serializer};
+		use serde_json::value::RawValue;
+
+		pub fn serialize<S: Serializer>(n: &Option<BigInt>, serializer: S) -> Result<S::Ok, S::Error> {
+			match n {
+				Some(n) => super::serialize(n, serializer),
+				None => serializer.serialize_none(),
+			}
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<BigInt>, D::Error> {
+			let raw = Option::<Box<RawValue>>::deserialize(deserializer)?;
+			raw.map(|raw| raw.get().parse().map_err(serde::de::Error::custom)).transpose()
+		}
+	}
+}`,
+	urlModule: `/// Serializes Url as the object Go's encoding/json makes of url.URL, which only holds absolute URLs in Rust
+pub mod go_url {
+	use serde::{Deserialize, Deserializer, Serialize, Serializer};
+	use url::Url;
+
+	#[derive(Default, Serialize, Deserialize)]
+	#[serde(rename_all = "PascalCase", default)]
+	struct GoUrl {
+		scheme: String,
+		opaque: String,
+		user: Option<Userinfo>,
+		host: String,
+		path: String,
+		raw_path: String,
+		omit_host: bool,
+		force_query: bool,
+		raw_query: String,
+		fragment: String,
+		raw_fragment: String,
+	}
+
+	/// Go's url.Userinfo has no exported fields, so only whether it is set is serialized
+	#[derive(Default, Serialize, Deserialize)]
+	struct Userinfo {}
+
+	fn to_go(url: &Url) -> GoUrl {
+		let raw_query = url.query().unwrap_or_default().to_string();
+		let fragment = url.fragment().unwrap_or_default().to_string();
+		if url.cannot_be_a_base() {
+			return GoUrl { scheme: url.scheme().to_string(), opaque: url.path().to_string(), raw_query, fragment, ..GoUrl::default() };
+		}
+
+		let mut host = url.host_str().unwrap_or_default().to_string();
+		if let Some(port) = url.port() {
+			host = format!("{host}:{port}");
+		}
+		GoUrl {
+			scheme: url.scheme().to_string(),
+			user: (!url.username().is_empty() || url.password().is_some()).then(Userinfo::default),
+			host,
+			path: url.path().to_string(),
+			raw_query,
+			fragment,
+			..GoUrl::default()
+		}
+	}
+
+	fn from_go(url: GoUrl) -> Result<Url, url::ParseError> {
+		let mut s = if url.opaque.is_empty() {
+			let path = if url.raw_path.is_empty() { &url.path } else { &url.raw_path };
+			format!("{}://{}{}", url.scheme, url.host, path)
+		} else {
+			format!("{}:{}", url.scheme, url.opaque)
+		};
+		if url.force_query || !url.raw_query.is_empty() {
+			s.push('?');
+			s.push_str(&url.raw_query);
+		}
+		if !url.fragment.is_empty() {
+			s.push('#');
+			s.push_str(&url.fragment);
+		}
+		Url::parse(&s)
+	}
+
+	pub fn serialize<S: Serializer>(url: &Url, serializer: S) -> Result<S::Ok, S::Error> {
+		to_go(url).serialize(serializer)
+	}
+
+	pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Url, D::Error> {
+		from_go(GoUrl::deserialize(deserializer)?).map_err(serde::de::Error::custom)
+	}
+
+	/// Serializes Option<Url> as the object of url.URL or null
+	pub mod option {
+		use serde::{Deserialize, Deserializer, Serialize, Serializer};
+		use url::Url;
+
+		pub fn serialize<S: Serializer>(url: &Option<Url>, serializer: S) -> Result<S::Ok, S::Error> {
+			url.as_ref().map(super::to_go).serialize(serializer)
+		}
+
+		pub fn deserialize<'de, D: Deserializer<'de>>(deserializer: D) -> Result<Option<Url>, D::Error> {
+			let url = Option::<super::GoUrl>::deserialize(deserializer)?;
+			url.map(super::from_go).transpose().map_err(serde::de::Error::custom)
+		}
+	}
+}`,
+}
+
+// wireOverride returns the built-in override of the standard library type name
+func (g *Generator) wireOverride(name string) (Override, bool) {
+	w, ok := wireTypes[name]
+	if !ok {
+		return Override{}, false
+	}
+
+	o := Override{RustType: w.rustType, Imports: w.imports}
+	if w.adapter != "" {
+		o.SerdeWith = g.qualify("", w.adapter)
+		o.OptionSerdeWith = o.SerdeWith + "::option"
+	}
+
+	return o, true
+}
+
+// builtinWireType returns the wire type t is mapped onto when it is not overridden
+func (g *Generator) builtinWireType(t rstypes.Type) (wireType, bool) {
+	name := goTypeName(t)
+	if _, ok := g.Overrides[name]; ok {
+		return wireType{}, false
+	}
+
+	w, ok := wireTypes[name]
+
+	return w, ok
+}
+
+// useAdapter records the adapter of the wire type t to be generated into the root module
+func (g *Generator) useAdapter(t rstypes.Type) {
+	if w, ok := g.builtinWireType(t); ok && w.adapter != "" {
+		g.adapters[w.adapter] = true
+	}
+}
+
+// adapterCode returns the serde adapter modules of the byte slices and wire types which are generated
+func (g *Generator) adapterCode() string {
+	modules := make([]string, 0, len(g.adapters))
+	for module := range g.adapters {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	code := g.base64Code()
+	for _, module := range modules {
+		code += wireAdapters[module] + "\n\n"
+	}
+
+	return code
+}
+
+// analyzePartialEq finds the generated types which contain wire types without PartialEq,
+// directly or through other generated types, so PartialEq and the derives built on it are left out
+func (g *Generator) analyzePartialEq() {
+	g.noPartialEq = make(map[string]bool)
+
+	var lacks func(t rstypes.Type) bool
+	lacks = func(t rstypes.Type) bool {
+		if t == nil {
+			return false
+		}
+		if w, ok := g.builtinWireType(t); ok {
+			return w.noPartialEq
+		}
+		if _, ok := g.lookupOverride(t); ok {
+			return false
+		}
+
+		switch v := t.(type) {
+		case *rstypes.Struct:
+			// Derives on generic structs need PartialEq of their type arguments
+			for _, arg := range v.TypeArgs {
+				if lacks(arg) {
+					return true
+				}
+			}
+			if key := g.nodeKey(v); key != "" {
+				return g.noPartialEq[key]
+			}
+			for _, f := range g.structFields(v) {
+				if lacks(f.Type) {
+					return true
+				}
+			}
+		case *rstypes.Enum:
+			if key := g.nodeKey(v); key != "" {
+				return g.noPartialEq[key]
+			}
+		case *rstypes.Tuple:
+			if v.Name != "" {
+				return g.noPartialEq[g.nodeKey(v)]
+			}
+			for _, elem := range v.Types {
+				if lacks(elem) {
+					return true
+				}
+			}
+		case *rstypes.Nullable:
+			return lacks(v.Inner)
+		case *rstypes.Vec:
+			return lacks(v.Inner)
+		case *rstypes.Array:
+			return lacks(v.Inner)
+		case *rstypes.Map:
+			return lacks(v.Key) || lacks(v.Value)
+		}
+
+		return false
+	}
+
+	contents := func(t rstypes.Type) []rstypes.Type {
+		types := make([]rstypes.Type, 0)
+		switch v := t.(type) {
+		case *rstypes.Struct:
+			for _, f := range g.structFields(v) {
+				types = append(types, f.Type)
+			}
+		case *rstypes.Tuple:
+			types = append(types, v.Types...)
+		case *rstypes.Enum:
+			for _, variant := range v.Variants {
+				types = append(types, variant.Type)
+			}
+		}
+
+		return types
+	}
+
+	// Types without PartialEq spread to the types containing them until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for t, key := range g.recursion.keys {
+			if g.noPartialEq[key] {
+				continue
+			}
+			for _, c := range contents(t) {
+				if lacks(c) {
+					g.noPartialEq[key] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// partialEqDerives are left out of the derives of types without PartialEq
+var partialEqDerives = map[string]bool{
+	"PartialEq":  true,
+	"Eq":         true,
+	"Hash":       true,
+	"PartialOrd": true,
+	"Ord":        true,
+}
@@ -0,0 +1,25 @@
+package loader
+
+import (
+	"go/types"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+func (p *pkgLoader) parseBasic(t *types.Basic) rstypes.Type {
+	switch {
+	case t.Info()&types.IsComplex != 0:
+		panic(&unsupportedTypeError{typ: t})
+	case t.Info()&(types.IsInteger|types.IsFloat) != 0:
+		num := &rstypes.Number{}
+		num.SetRawType(t.Kind())
+
+		return num
+	case t.Info()&types.IsBoolean != 0:
+		return &rstypes.Boolean{}
+	case t.Info()&types.IsString != 0:
+		return &rstypes.String{}
+	default:
+		panic(&unsupportedTypeError{typ: t})
+	}
+}
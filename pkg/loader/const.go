@@ -0,0 +1,64 @@
+package loader
+
+import (
+	"go/constant"
+	"go/types"
+	"sort"
+)
+
+type constCandidate struct {
+	Key   string
+	Value interface{}
+}
+
+// enumCandidates returns the exported constants of the named type t in declaration order
+func (p *pkgLoader) enumCandidates(t *types.Named) []constCandidate {
+	scope := t.Obj().Pkg().Scope()
+
+	consts := make([]*types.Const, 0)
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+
+		if !ok || !c.Exported() || !types.Identical(c.Type(), t) {
+			continue
+		}
+
+		consts = append(consts, c)
+	}
+
+	sort.SliceStable(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	candidates := make([]constCandidate, 0, len(consts))
+	for _, c := range consts {
+		if v, ok := constValue(c.Val()); ok {
+			candidates = append(candidates, constCandidate{
+				Key:   c.Name(),
+				Value: v,
+			})
+		}
+	}
+
+	return candidates
+}
+
+func constValue(val constant.Value) (interface{}, bool) {
+	switch val.Kind() {
+	case constant.Int:
+		if v, ok := constant.Int64Val(val); ok {
+			return v, true
+		}
+		if v, ok := constant.Uint64Val(val); ok {
+			return v, true
+		}
+	case constant.Float:
+		if v, ok := constant.Float64Val(val); ok {
+			return v, true
+		}
+	case constant.String:
+		return constant.StringVal(val), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,98 @@
+package loader
+
+import (
+	"go/types"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// namedKey returns the name t is loaded as, which is the name without type parameters for generic declarations
+func namedKey(t *types.Named) string {
+	if t.TypeParams().Len() == 0 || t.TypeArgs().Len() != 0 || t.Obj().Pkg() == nil {
+		return t.String()
+	}
+
+	return t.Obj().Pkg().Path() + "." + t.Obj().Name()
+}
+
+// parseInstance returns a reference to the declaration of a generic struct with the type arguments of t,
+// or nil when t is not an instance of an exported generic struct
+func (p *pkgLoader) parseInstance(t *types.Named, dep bool) rstypes.Type {
+	if t.TypeArgs().Len() == 0 {
+		return nil
+	}
+
+	origin := t.Origin()
+	if _, ok := origin.Underlying().(*types.Struct); !ok || !p.exported(origin, dep) {
+		return nil
+	}
+
+	// Registers the declaration, which is still being parsed when the instance is found in its own fields
+	if _, ok := p.parseNamed(origin, dep).(*rstypes.Struct); !ok {
+		return nil
+	}
+
+	args := make([]rstypes.Type, 0, t.TypeArgs().Len())
+	for i := 0; i < t.TypeArgs().Len(); i++ {
+		args = append(args, p.parseType(t.TypeArgs().At(i), true))
+	}
+
+	instance := &rstypes.Struct{
+		Name:     namedKey(origin),
+		TypeArgs: args,
+	}
+	instance.SetGoType(namedKey(origin))
+
+	return instance
+}
+
+// parseTypeParams parses the type parameters of a generic declaration
+func (p *pkgLoader) parseTypeParams(list *types.TypeParamList) []*rstypes.TypeParam {
+	if list.Len() == 0 {
+		return nil
+	}
+
+	params := make([]*rstypes.TypeParam, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		//nolint
+		params = append(params, p.parseTypeParam(list.At(i)).(*rstypes.TypeParam))
+	}
+
+	return params
+}
+
+func (p *pkgLoader) parseTypeParam(u *types.TypeParam) rstypes.Type {
+	param := &rstypes.TypeParam{
+		Name:       u.Obj().Name(),
+		Constraint: types.TypeString(u.Constraint(), (*types.Package).Name),
+	}
+
+	if iface, ok := u.Constraint().Underlying().(*types.Interface); ok {
+		param.Comparable = iface.IsComparable()
+		for i := 0; i < iface.NumMethods(); i++ {
+			param.Methods = append(param.Methods, iface.Method(i).Name())
+		}
+		param.Union = hasUnion(iface)
+	}
+
+	return param
+}
+
+// hasUnion reports whether a constraint restricts the types to a union like ~int | ~string
+func hasUnion(iface *types.Interface) bool {
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		switch e := iface.EmbeddedType(i).Underlying().(type) {
+		case *types.Union:
+			return true
+		case *types.Interface:
+			if hasUnion(e) {
+				return true
+			}
+		default:
+			// A single type like ~int
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,389 @@
+// Package loader loads Go packages with golang.org/x/tools/go/packages into rstypes
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"github.com/drewstone/go2rs/pkg/util"
+	"golang.org/x/tools/go/packages"
+)
+
+// Loader loads a Go package and converts its types into rstypes
+type Loader struct {
+	pkgs []*packages.Package
+
+	types       map[string]rstypes.Type
+	parsing     map[string]rstypes.Type
+	basePackage string
+
+	Filter func(opt *FilterOpt) bool
+	// ForceMapNonNullable interprets maps as non-nullable
+	ForceMapNonNullable bool
+	// IgnoreOmittedJSONField is a flag to ignore omitted json fields
+	IgnoreOmittedJSONField bool
+}
+
+// NewLoader loads the Go package in dir
+func NewLoader(dir string, filter func(*FilterOpt) bool) (*Loader, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedCompiledGoFiles |
+			packages.NeedSyntax |
+			packages.NeedTypes |
+			packages.NeedTypesInfo,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := visitErrors(pkgs); err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	return &Loader{
+		pkgs:        pkgs,
+		basePackage: pkgs[0].PkgPath,
+		Filter:      filter,
+	}, nil
+}
+
+func visitErrors(pkgs []*packages.Package) error {
+	errs := make([]string, 0)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for i := range pkg.Errors {
+			errs = append(errs, pkg.Errors[i].Error())
+		}
+	})
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errs, "\n"))
+}
+
+// GetBasePackage returns the import path of the loaded package
+func (l *Loader) GetBasePackage() string {
+	return l.basePackage
+}
+
+// SourceHash returns the SHA1 of the import path and the Go files of the loaded package.
+// It only depends on the file names and contents, so it is the same on every machine.
+func (l *Loader) SourceHash() (string, error) {
+	files := make([]string, 0)
+	for _, pkg := range l.pkgs {
+		files = append(files, pkg.GoFiles...)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+
+	var buf strings.Builder
+	buf.WriteString(l.basePackage + "\n")
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+
+		buf.WriteString(filepath.Base(file) + "\n")
+		buf.Write(b)
+	}
+
+	return util.SHA1(buf.String()), nil
+}
+
+// Load converts the types in the loaded package into rstypes
+func (l *Loader) Load() (res map[string]rstypes.Type, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			var ok bool
+			err, ok = e.(error)
+
+			if !ok {
+				err = fmt.Errorf("%+v", e)
+			}
+		}
+	}()
+
+	l.types = make(map[string]rstypes.Type)
+	l.parsing = make(map[string]rstypes.Type)
+
+	for _, pkg := range l.pkgs {
+		scope := pkg.Types.Scope()
+
+		for _, name := range scope.Names() {
+			v, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || v.IsAlias() {
+				continue
+			}
+
+			t, ok := v.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+
+			// Only generic structs have a Rust equivalent
+			if _, ok := t.Underlying().(*types.Struct); !ok && t.TypeParams().Len() != 0 {
+				continue
+			}
+
+			pl := &pkgLoader{
+				Loader: l,
+				pkg:    pkg.Types,
+				fset:   pkg.Fset,
+			}
+
+			if parsed := pl.parseType(t, false); parsed != nil {
+				parsed.SetPackageName(pkg.Name)
+			}
+		}
+	}
+
+	return l.types, nil
+}
+
+func (l *Loader) exported(t *types.Named, dep bool) bool {
+	opt := &FilterOpt{
+		Package:    t.Obj().Pkg().Path(),
+		Name:       t.Obj().Name(),
+		Exported:   t.Obj().Exported(),
+		Dependency: dep,
+	}
+	for _, pkg := range l.pkgs {
+		if pkg.Types == t.Obj().Pkg() {
+			opt.BasePackage = true
+		}
+	}
+
+	return l.Filter(opt)
+}
+
+// unsupportedTypeError is raised for Go types without a Rust equivalent
+type unsupportedTypeError struct {
+	typ types.Type
+
+	// field and position are the struct field or method with the type if any
+	field    string
+	position *token.Position
+	// kind is "field" or "method"
+	kind string
+}
+
+func (e *unsupportedTypeError) Error() string {
+	if e.field == "" {
+		return "unsupported type: " + e.typ.String()
+	}
+
+	kind := e.kind
+	if kind == "" {
+		kind = "field"
+	}
+
+	msg := fmt.Sprintf("%s %s has unsupported type %s", kind, e.field, e.typ)
+	if e.position != nil {
+		msg = fmt.Sprintf("%s:%d: %s", e.position.Filename, e.position.Line, msg)
+	}
+
+	return msg
+}
+
+// pkgLoader converts the types of a types.Package
+type pkgLoader struct {
+	*Loader
+	pkg  *types.Package
+	fset *token.FileSet
+}
+
+func (p *pkgLoader) position(pos token.Pos) *token.Position {
+	if !pos.IsValid() {
+		return nil
+	}
+
+	position := p.fset.Position(pos)
+
+	return &position
+}
+
+func (p *pkgLoader) parseNamed(t *types.Named, dep bool) rstypes.Type {
+	if t.String() == "time.Time" {
+		date := &rstypes.Date{}
+		date.SetGoType(t.String())
+
+		return date
+	}
+	if isError(t) {
+		return errorTrait()
+	}
+	if stream := p.parseIterator(t); stream != nil {
+		return stream
+	}
+	if instance := p.parseInstance(t, dep); instance != nil {
+		return instance
+	}
+
+	name := namedKey(t)
+	exported := p.exported(t, dep)
+
+	if exported {
+		if tt, ok := p.types[name]; ok {
+			return tt
+		}
+	} else if !dep {
+		return nil
+	}
+
+	// For recursive references to the same struct or trait
+	if dummy, ok := p.parsing[name]; ok {
+		return dummy
+	}
+
+	// Interfaces with methods are service contracts, while empty ones hold any value.
+	// Only the selected interfaces become traits, and encoding/json marshals values of the others,
+	// like fmt.Stringer, by their dynamic values.
+	if iface, ok := t.Underlying().(*types.Interface); ok && iface.IsMethodSet() && iface.NumMethods() != 0 {
+		if !exported {
+			dynamic := p.parseInterface(iface)
+			dynamic.SetGoType(name)
+
+			return dynamic
+		}
+		return p.parseTrait(t, iface, exported)
+	}
+
+	var dummy *rstypes.Struct
+	if _, ok := t.Underlying().(*types.Struct); ok {
+		dummy = &rstypes.Struct{}
+		p.parsing[name] = dummy
+		defer delete(p.parsing, name)
+
+		if exported {
+			p.types[name] = dummy
+		}
+	}
+
+	typ := p.parseType(t.Underlying(), true)
+
+	if dummy != nil {
+		//nolint
+		strct := typ.(*rstypes.Struct)
+
+		dummy.Fields = strct.Fields
+		dummy.TypeParams = p.parseTypeParams(t.TypeParams())
+		typ = dummy
+	}
+
+	typ.SetGoType(name)
+
+	if exported {
+		if enum, ok := typ.(rstypes.Enumerable); ok {
+			for _, c := range p.enumCandidates(t) {
+				enum.AddCandidates(c.Key, c.Value)
+			}
+		}
+
+		if named, ok := typ.(rstypes.NamedType); ok {
+			named.SetName(name)
+		}
+
+		typ.SetPosition(p.position(t.Obj().Pos()))
+
+		p.types[name] = typ
+	}
+
+	return typ
+}
+
+func (p *pkgLoader) parsePointer(u *types.Pointer) rstypes.Type {
+	return &rstypes.Nullable{
+		Inner: p.parseType(u.Elem(), true),
+	}
+}
+
+func (p *pkgLoader) parseSlice(u *types.Slice) rstypes.Type {
+	return &rstypes.Nullable{
+		Inner: &rstypes.Vec{
+			Inner: p.parseType(u.Elem(), true),
+		},
+	}
+}
+
+func (p *pkgLoader) parseArray(u *types.Array) rstypes.Type {
+	return &rstypes.Array{
+		Inner: p.parseType(u.Elem(), true),
+		Size:  uint64(u.Len()),
+	}
+}
+
+func (p *pkgLoader) parseMap(u *types.Map) rstypes.Type {
+	keyType := p.parseType(u.Key(), true)
+
+	if !keyType.UsedAsMapKey() {
+		panic(keyType.String() + " cannot be used as key")
+	}
+
+	m := &rstypes.Map{
+		Key:   keyType,
+		Value: p.parseType(u.Elem(), true),
+	}
+
+	if p.ForceMapNonNullable {
+		return m
+	}
+
+	return &rstypes.Nullable{
+		Inner: m,
+	}
+}
+
+func (p *pkgLoader) parseInterface(_ *types.Interface) rstypes.Type {
+	return &rstypes.Any{}
+}
+
+func (p *pkgLoader) parseType(u types.Type, dep bool) rstypes.Type {
+	switch u := u.(type) {
+	case *types.Named:
+		return p.parseNamed(u, dep)
+	case *types.Alias:
+		return p.parseType(types.Unalias(u), dep)
+	case *types.Struct:
+		return p.parseStruct(u)
+	case *types.Basic:
+		return p.parseBasic(u)
+	case *types.Pointer:
+		return p.parsePointer(u)
+	case *types.Slice:
+		return p.parseSlice(u)
+	case *types.Array:
+		return p.parseArray(u)
+	case *types.Map:
+		return p.parseMap(u)
+	case *types.Interface:
+		return p.parseInterface(u)
+	case *types.Signature:
+		return p.parseSignature(u)
+	case *types.Chan:
+		return p.parseChan(u)
+	case *types.TypeParam:
+		return p.parseTypeParam(u)
+	default:
+		panic(&unsupportedTypeError{typ: u})
+	}
+}
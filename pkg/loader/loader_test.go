@@ -0,0 +1,438 @@
+package loader
+
+import (
+	"go/types"
+	"slices"
+	"strings"
+	"testing"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+const successPkg = "github.com/drewstone/go2rs/pkg/loader/testdata/success"
+
+func load(t *testing.T, dir string) map[string]rstypes.Type {
+	t.Helper()
+
+	l, err := NewLoader(dir, Default)
+	if err != nil {
+		t.Fatalf("NewLoader() failed: %+v", err)
+	}
+
+	res, err := l.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %+v", err)
+	}
+
+	return res
+}
+
+func TestLoader_Numbers(t *testing.T) {
+	res := load(t, "./testdata/success")
+
+	numbers, ok := res[successPkg+".Numbers"].(*rstypes.Struct)
+	if !ok {
+		t.Fatalf("Numbers was not loaded: %v", res)
+	}
+
+	tests := []struct {
+		field string
+		kind  types.BasicKind
+		want  string
+	}{
+		{"Int", types.Int, "isize"},
+		{"Int8", types.Int8, "i8"},
+		{"Int16", types.Int16, "i16"},
+		{"Int32", types.Int32, "i32"},
+		{"Int64", types.Int64, "i64"},
+		{"Uint", types.Uint, "usize"},
+		{"Uint8", types.Uint8, "u8"},
+		{"Uint16", types.Uint16, "u16"},
+		{"Uint32", types.Uint32, "u32"},
+		{"Uint64", types.Uint64, "u64"},
+		{"Uintptr", types.Uintptr, "usize"},
+		{"Float32", types.Float32, "f32"},
+		{"Float64", types.Float64, "f64"},
+		{"Byte", types.Uint8, "u8"},
+		{"Rune", types.Int32, "i32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			num, ok := numbers.Fields[tt.field].Type.(*rstypes.Number)
+			if !ok {
+				t.Fatalf("expected *rstypes.Number, got %T", numbers.Fields[tt.field].Type)
+			}
+
+			if num.RawType != tt.kind {
+				t.Errorf("RawType = %v, want %v", num.RawType, tt.kind)
+			}
+			if got := num.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoader_Data(t *testing.T) {
+	res := load(t, "./testdata/success")
+
+	if _, ok := res[successPkg+".base"]; ok {
+		t.Errorf("unexported type was loaded")
+	}
+
+	data, ok := res[successPkg+".Data"].(*rstypes.Struct)
+	if !ok {
+		t.Fatalf("Data was not loaded: %v", res)
+	}
+
+	wantFields := []string{"base", "status", "priority", "tags", "hash", "labels", "next", "created_at"}
+	if len(data.Fields) != len(wantFields) {
+		t.Errorf("expected %d fields, got %d: %v", len(wantFields), len(data.Fields), data.Fields)
+	}
+	for i, name := range wantFields {
+		field, ok := data.Fields[name]
+		if !ok {
+			t.Errorf("field %s is missing", name)
+			continue
+		}
+		if field.FieldIndex != i {
+			t.Errorf("field %s has index %d, want %d", name, field.FieldIndex, i)
+		}
+	}
+
+	base := data.Fields["base"]
+	if !base.Embedded {
+		t.Errorf("base should be embedded")
+	}
+	if s, ok := base.Type.(*rstypes.Struct); !ok || s.Name != "" || len(s.Fields) != 1 {
+		t.Errorf("base should be an anonymous struct with id: %v", base.Type)
+	}
+
+	status, ok := data.Fields["status"].Type.(*rstypes.String)
+	if !ok || status != res[successPkg+".Status"] {
+		t.Fatalf("status does not refer to Status: %v", data.Fields["status"].Type)
+	}
+	if want := []string{"OK", "Failure"}; len(status.Enum) != 2 || status.Enum[0] != want[0] || status.Enum[1] != want[1] {
+		t.Errorf("Status enum = %v, want %v", status.Enum, want)
+	}
+
+	priority := data.Fields["priority"]
+	if !priority.Optional {
+		t.Errorf("priority should be optional")
+	}
+	if num, ok := priority.Type.(*rstypes.Number); !ok || num.String() != "i8" || len(num.Enum) != 2 {
+		t.Errorf("unexpected priority type: %v", priority.Type)
+	}
+
+	if tags, ok := data.Fields["tags"].Type.(*rstypes.Nullable); !ok {
+		t.Errorf("unexpected tags type: %v", data.Fields["tags"].Type)
+	} else if _, ok := tags.Inner.(*rstypes.Vec); !ok {
+		t.Errorf("tags should be a Vec: %v", tags.Inner)
+	}
+
+	if arr, ok := data.Fields["hash"].Type.(*rstypes.Array); !ok || arr.Size != 4 {
+		t.Errorf("unexpected hash type: %v", data.Fields["hash"].Type)
+	}
+
+	if _, ok := data.Fields["created_at"].Type.(*rstypes.Date); !ok {
+		t.Errorf("unexpected created_at type: %v", data.Fields["created_at"].Type)
+	}
+
+	next := data.Fields["next"]
+	if !next.Optional || next.Type != data {
+		t.Errorf("next should be an optional recursive reference: %v", next.Type)
+	}
+
+	if pos := data.GetPosition(); pos == nil || pos.Line == 0 {
+		t.Errorf("position of Data is missing")
+	}
+}
+
+func TestLoader_SourceHash(t *testing.T) {
+	hash := func() string {
+		l, err := NewLoader("./testdata/success", Default)
+		if err != nil {
+			t.Fatalf("NewLoader() failed: %+v", err)
+		}
+
+		hash, err := l.SourceHash()
+		if err != nil {
+			t.Fatalf("SourceHash() failed: %+v", err)
+		}
+
+		return hash
+	}
+
+	got := hash()
+	if len(got) != 40 {
+		t.Errorf("SourceHash() = %q, want a SHA1 in hex", got)
+	}
+	if again := hash(); again != got {
+		t.Errorf("SourceHash() is not stable: %s != %s", got, again)
+	}
+}
+
+func TestLoader_Unsupported(t *testing.T) {
+	l, err := NewLoader("./testdata/unsupported", Default)
+	if err != nil {
+		t.Fatalf("NewLoader() failed: %+v", err)
+	}
+
+	_, err = l.Load()
+	if err == nil {
+		t.Fatal("expected an error for an unsupported field type")
+	}
+
+	if want := "main.go:5: field Meta has unsupported type complex128"; !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("Load() error = %q, want suffix %q", err, want)
+	}
+}
+
+func TestLoader_InterfaceFields(t *testing.T) {
+	const servicePkg = "github.com/drewstone/go2rs/pkg/loader/testdata/service"
+	res := load(t, "./testdata/service")
+
+	drawing, ok := res[servicePkg+".Drawing"].(*rstypes.Struct)
+	if !ok {
+		t.Fatalf("Drawing was not loaded as a struct: %v", res[servicePkg+".Drawing"])
+	}
+
+	// Values of interfaces which are not selected are marshaled by their dynamic values
+	if label, ok := drawing.Fields["label"].Type.(*rstypes.Any); !ok || label.GetGoType() != "fmt.Stringer" {
+		t.Errorf("label should be Any: %v", drawing.Fields["label"].Type)
+	}
+	if shape, ok := drawing.Fields["shape"].Type.(*rstypes.Trait); !ok || shape != res[servicePkg+".Shape"] {
+		t.Errorf("shape should refer to the Shape trait: %v", drawing.Fields["shape"].Type)
+	}
+}
+
+func TestLoader_Traits(t *testing.T) {
+	const servicePkg = "github.com/drewstone/go2rs/pkg/loader/testdata/service"
+	res := load(t, "./testdata/service")
+
+	if _, ok := res[servicePkg+".Payload"].(*rstypes.Any); !ok {
+		t.Errorf("Payload should be Any: %v", res[servicePkg+".Payload"])
+	}
+
+	svc, ok := res[servicePkg+".OrderService"].(*rstypes.Trait)
+	if !ok {
+		t.Fatalf("OrderService was not loaded as a trait: %v", res[servicePkg+".OrderService"])
+	}
+
+	supertraits := make([]string, 0)
+	for _, s := range svc.Supertraits {
+		supertraits = append(supertraits, s.Name)
+	}
+	// Interfaces outside the selected types, like fmt.Stringer, are not traits
+	if want := servicePkg + ".Reader"; strings.Join(supertraits, ",") != want {
+		t.Errorf("Supertraits = %v, want %s", supertraits, want)
+	}
+	if _, ok := res["fmt.Stringer"]; ok {
+		t.Errorf("fmt.Stringer should not be loaded")
+	}
+
+	methods := make(map[string]*rstypes.Function)
+	for _, m := range svc.Methods {
+		if !m.IsMethod || m.Receiver != svc {
+			t.Errorf("%s should be a method of OrderService", m.Name)
+		}
+		methods[m.Name] = m
+	}
+	if len(methods) != 5 {
+		t.Fatalf("expected 5 methods, got %d", len(methods))
+	}
+
+	list := methods["List"]
+	if len(list.Params) != 2 || list.Params[0].Name != "limit" || list.Params[1].Name != "tags" {
+		t.Errorf("List should drop the context: %v", list)
+	}
+	if _, ok := list.Params[1].Type.(*rstypes.Vec); !ok {
+		t.Errorf("variadic tags should be a Vec: %v", list.Params[1].Type)
+	}
+	if result, ok := list.Returns.(*rstypes.Result); !ok {
+		t.Errorf("List should return a Result: %v", list.Returns)
+	} else if err, ok := result.Err.(*rstypes.Trait); !ok || !err.IsError() {
+		t.Errorf("List should fail with error: %v", result.Err)
+	}
+
+	if result, ok := methods["Delete"].Returns.(*rstypes.Result); !ok {
+		t.Errorf("Delete should return a Result: %v", methods["Delete"].Returns)
+	} else if _, ok := result.Ok.(*rstypes.Unit); !ok {
+		t.Errorf("Delete should return Result<(), Error>: %v", result)
+	}
+
+	if tuple, ok := methods["Stats"].Returns.(*rstypes.Tuple); !ok || len(tuple.Types) != 2 {
+		t.Errorf("Stats should return a tuple: %v", methods["Stats"].Returns)
+	}
+	if _, ok := methods["Watch"].Params[0].Type.(*rstypes.Function); !ok {
+		t.Errorf("onChange should be a function: %v", methods["Watch"].Params[0].Type)
+	}
+	if methods["Close"].Returns != nil {
+		t.Errorf("Close should return nothing: %v", methods["Close"].Returns)
+	}
+
+	reader, ok := res[servicePkg+".Reader"].(*rstypes.Trait)
+	if !ok || len(reader.Methods) != 1 {
+		t.Fatalf("Reader was not loaded as a trait: %v", res[servicePkg+".Reader"])
+	}
+	if result, ok := reader.Methods[0].Returns.(*rstypes.Result); !ok || result.Ok != res[servicePkg+".Order"] {
+		t.Errorf("Get should return Result<Order, Error>: %v", reader.Methods[0].Returns)
+	}
+}
+
+func TestLoader_Streams(t *testing.T) {
+	const streamsPkg = "github.com/drewstone/go2rs/pkg/loader/testdata/streams"
+	res := load(t, "./testdata/streams")
+
+	source, ok := res[streamsPkg+".EventSource"].(*rstypes.Trait)
+	if !ok {
+		t.Fatalf("EventSource was not loaded as a trait: %v", res[streamsPkg+".EventSource"])
+	}
+
+	event := res[streamsPkg+".Event"]
+	streams := make(map[string]rstypes.Type)
+	for _, m := range source.Methods {
+		streams[m.Name] = m.Returns
+		if len(m.Params) != 0 {
+			streams[m.Name] = m.Params[0].Type
+		}
+	}
+
+	tests := []struct {
+		method    string
+		direction rstypes.StreamDirection
+	}{
+		{"Events", rstypes.StreamReceive},
+		{"All", rstypes.StreamReceive},
+		{"Indexed", rstypes.StreamReceive},
+		{"Publish", rstypes.StreamSend},
+		{"Pipe", rstypes.StreamBoth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			stream, ok := streams[tt.method].(*rstypes.Stream)
+			if !ok {
+				t.Fatalf("expected *rstypes.Stream, got %T", streams[tt.method])
+			}
+			if stream.Direction != tt.direction {
+				t.Errorf("Direction = %v, want %v", stream.Direction, tt.direction)
+			}
+
+			inner := stream.Inner
+			if tuple, ok := inner.(*rstypes.Tuple); ok && len(tuple.Types) == 2 {
+				inner = tuple.Types[1]
+			}
+			if inner != event {
+				t.Errorf("Inner = %v, want Event", stream.Inner)
+			}
+		})
+	}
+
+	sub, ok := res[streamsPkg+".Subscription"].(*rstypes.Struct)
+	if !ok {
+		t.Fatalf("Subscription was not loaded: %v", res[streamsPkg+".Subscription"])
+	}
+	if _, ok := sub.Fields["updates"].Type.(*rstypes.Stream); !ok {
+		t.Errorf("updates should be a stream: %v", sub.Fields["updates"].Type)
+	}
+}
+
+func TestLoader_Generics(t *testing.T) {
+	const genericsPkg = "github.com/drewstone/go2rs/pkg/loader/testdata/generics"
+	res := load(t, "./testdata/generics")
+
+	params := func(name string) []*rstypes.TypeParam {
+		t.Helper()
+
+		s, ok := res[genericsPkg+"."+name].(*rstypes.Struct)
+		if !ok {
+			t.Fatalf("%s was not loaded as a struct: %v", name, res[genericsPkg+"."+name])
+		}
+
+		return s.TypeParams
+	}
+
+	if p := params("Page"); len(p) != 1 || p[0].Name != "T" || p[0].Constraint != "any" || p[0].Comparable {
+		t.Errorf("unexpected type parameters of Page: %v", p)
+	}
+	if p := params("Index"); len(p) != 2 || p[0].Name != "K" || !p[0].Comparable || p[1].Name != "V" {
+		t.Errorf("unexpected type parameters of Index: %v", p)
+	}
+	if p := params("Range"); len(p) != 1 || p[0].Constraint != "cmp.Ordered" || !p[0].Union {
+		t.Errorf("unexpected type parameters of Range: %v", p)
+	}
+	if p := params("Labeled"); len(p) != 1 || len(p[0].Methods) != 1 || p[0].Methods[0] != "String" || p[0].Union {
+		t.Errorf("unexpected type parameters of Labeled: %v", p)
+	}
+	if _, ok := res[genericsPkg+".List"]; ok {
+		t.Errorf("generic List should not be loaded")
+	}
+
+	orders, ok := res[genericsPkg+".Orders"].(*rstypes.Struct)
+	if !ok {
+		t.Fatalf("Orders was not loaded: %v", res[genericsPkg+".Orders"])
+	}
+
+	page, ok := orders.Fields["page"].Type.(*rstypes.Struct)
+	if !ok || page.Name != genericsPkg+".Page" || len(page.TypeArgs) != 1 || page.TypeArgs[0] != res[genericsPkg+".Order"] {
+		t.Errorf("page should be Page<Order>: %v", orders.Fields["page"].Type)
+	}
+	if got := orders.Fields["index"].Type.String(); got != genericsPkg+".Index<String, "+genericsPkg+".Order>" {
+		t.Errorf("index = %s, want Index<String, Order>", got)
+	}
+	if tree := orders.Fields["tree"]; !tree.Optional || tree.Type.String() != genericsPkg+".Tree<"+genericsPkg+".Order>" {
+		t.Errorf("tree should be an optional Tree<Order>: %v", tree.Type)
+	}
+	if list, ok := orders.Fields["list"].Type.(*rstypes.Nullable); !ok || list.Inner.String() != "Vec<"+genericsPkg+".Order>" {
+		t.Errorf("list should be instantiated as Vec<Order>: %v", orders.Fields["list"].Type)
+	}
+
+	tree := res[genericsPkg+".Tree"].(*rstypes.Struct)
+	children := tree.Fields["children"].Type.(*rstypes.Nullable).Inner.(*rstypes.Vec).Inner
+	if child, ok := children.(*rstypes.Struct); !ok || child.Name != genericsPkg+".Tree" || child.TypeArgs[0].String() != "T" {
+		t.Errorf("children should be Vec<Tree<T>>: %v", children)
+	}
+}
+
+func TestLoader_WireTypes(t *testing.T) {
+	const wirePkg = "github.com/drewstone/go2rs/pkg/loader/testdata/wire"
+	res := load(t, "./testdata/wire")
+
+	settings, ok := res[wirePkg+".Settings"].(*rstypes.Struct)
+	if !ok {
+		t.Fatalf("Settings was not loaded as a struct: %v", res[wirePkg+".Settings"])
+	}
+
+	// Standard library types keep their names for the generator to map them
+	for field, goTypes := range map[string][]string{
+		"timeout":  {"time.Duration"},
+		"payload":  {"encoding/json.RawMessage", "encoding/json/jsontext.Value"},
+		"amount":   {"encoding/json.Number"},
+		"address":  {"net.IP"},
+		"peer":     {"net/netip.Addr"},
+		"endpoint": {"net/url.URL"},
+		// Optional slices are unwrapped from their Nullable without losing their names
+		"extra":   {"encoding/json.RawMessage", "encoding/json/jsontext.Value"},
+		"gateway": {"net.IP"},
+	} {
+		if got := settings.Fields[field].Type.GetGoType(); !slices.Contains(goTypes, got) {
+			t.Errorf("unexpected Go type of %s: %q", field, got)
+		}
+	}
+	for _, field := range []string{"extra", "gateway"} {
+		if !settings.Fields[field].Optional {
+			t.Errorf("%s is not optional", field)
+		}
+	}
+
+	for field, goType := range map[string]string{
+		"balance": "math/big.Int",
+		"filter":  "regexp.Regexp",
+	} {
+		nullable, ok := settings.Fields[field].Type.(*rstypes.Nullable)
+		if !ok || nullable.Inner.GetGoType() != goType {
+			t.Errorf("%s was not loaded as a pointer to %s: %v", field, goType, settings.Fields[field].Type)
+		}
+	}
+}
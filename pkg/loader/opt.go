@@ -0,0 +1,32 @@
+// Package loader loads Go packages with golang.org/x/tools/go/packages into rstypes
+package loader
+
+// FilterOpt is options to filter exported types
+type FilterOpt struct {
+	BasePackage bool
+	Package     string
+	Name        string
+	Exported    bool
+	// Dependency is true when the type is referenced by another exported type.
+	// The filter can be called more than once for the same type, and the type is
+	// exported as soon as it returns true once.
+	Dependency bool
+}
+
+// Default exports all types that are both exported and in the base package, and their dependencies
+var Default = func(opt *FilterOpt) bool {
+	if !opt.BasePackage {
+		return false
+	}
+
+	if !opt.Exported {
+		return false
+	}
+
+	return true
+}
+
+// All exports all types
+var All = func(opt *FilterOpt) bool {
+	return true
+}
@@ -0,0 +1,51 @@
+package loader
+
+import (
+	"go/types"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// parseChan converts a channel into a stream of its elements in the direction of the channel
+func (p *pkgLoader) parseChan(u *types.Chan) rstypes.Type {
+	stream := &rstypes.Stream{
+		Inner: p.parseType(u.Elem(), true),
+	}
+
+	switch u.Dir() {
+	case types.SendOnly:
+		stream.Direction = rstypes.StreamSend
+	case types.SendRecv:
+		stream.Direction = rstypes.StreamBoth
+	}
+
+	return stream
+}
+
+// parseIterator converts iter.Seq[V] into a stream of V and iter.Seq2[K, V] into a stream of (K, V),
+// or returns nil when t is not an iterator
+func (p *pkgLoader) parseIterator(t *types.Named) rstypes.Type {
+	obj := t.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != "iter" {
+		return nil
+	}
+
+	args := t.TypeArgs()
+	switch {
+	case obj.Name() == "Seq" && args.Len() == 1:
+		return &rstypes.Stream{
+			Inner: p.parseType(args.At(0), true),
+		}
+	case obj.Name() == "Seq2" && args.Len() == 2:
+		return &rstypes.Stream{
+			Inner: &rstypes.Tuple{
+				Types: []rstypes.Type{
+					p.parseType(args.At(0), true),
+					p.parseType(args.At(1), true),
+				},
+			},
+		}
+	}
+
+	return nil
+}
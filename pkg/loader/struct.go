@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"go/types"
+	"reflect"
+	"strings"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+const (
+	jsonTagOmitempty = "omitempty"
+)
+
+// jsonTag returns the field name and whether omitempty is set in the json tag
+func jsonTag(tag string) (name string, omitempty bool) {
+	parts := strings.Split(reflect.StructTag(tag).Get("json"), ",")
+
+	for _, opt := range parts[1:] {
+		if opt == jsonTagOmitempty {
+			omitempty = true
+		}
+	}
+
+	return parts[0], omitempty
+}
+
+// parseField parses the type of the struct field v and reports unsupported types at the field
+func (p *pkgLoader) parseField(v *types.Var) rstypes.Type {
+	defer func() {
+		if e := recover(); e != nil {
+			// The innermost field is the most precise
+			if u, ok := e.(*unsupportedTypeError); ok && u.field == "" {
+				u.field = v.Name()
+				u.position = p.position(v.Pos())
+			}
+			panic(e)
+		}
+	}()
+
+	return p.parseType(v.Type(), true)
+}
+
+func (p *pkgLoader) parseStruct(strct *types.Struct) rstypes.Type {
+	obj := &rstypes.Struct{
+		Fields: map[string]rstypes.StructField{},
+	}
+
+	idx := 0
+	for i := 0; i < strct.NumFields(); i++ {
+		v := strct.Field(i)
+		tag := strct.Tag(i)
+
+		if !v.Exported() && !v.Embedded() {
+			continue
+		}
+
+		name, optional := jsonTag(tag)
+
+		if name == "-" && !p.IgnoreOmittedJSONField {
+			continue
+		}
+
+		typ := p.parseField(v)
+
+		// Fields of embedded structs without a json name are promoted, which the generator resolves
+		embedded := false
+		if v.Embedded() && name == "" {
+			_, embedded = removeNullable(typ).(*rstypes.Struct)
+		}
+
+		if !v.Exported() && !embedded {
+			continue
+		}
+
+		if name == "" {
+			name = v.Name()
+		}
+
+		if embedded {
+			optional = false
+		} else if optional {
+			typ = removeNullable(typ)
+		}
+
+		obj.Fields[name] = rstypes.StructField{
+			RawName:    v.Name(),
+			RawTag:     tag,
+			FieldIndex: idx,
+			Type:       typ,
+			Optional:   optional,
+			Embedded:   embedded,
+			Position:   p.position(v.Pos()),
+		}
+		idx++
+	}
+
+	return obj
+}
+
+// removeNullable returns the type inside a nil slice, map or pointer.
+// Named slices and maps keep their Go type, which overrides and wire types are looked up by.
+func removeNullable(typ rstypes.Type) rstypes.Type {
+	if nullable, ok := typ.(*rstypes.Nullable); ok {
+		if name := nullable.GetGoType(); name != "" && nullable.Inner.GetGoType() == "" {
+			nullable.Inner.SetGoType(name)
+		}
+		return nullable.Inner
+	}
+
+	return typ
+}
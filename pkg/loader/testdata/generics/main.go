@@ -0,0 +1,43 @@
+package generics
+
+import (
+	"cmp"
+	"fmt"
+)
+
+type Order struct {
+	ID string `json:"id"`
+}
+
+type Page[T any] struct {
+	Items []T    `json:"items"`
+	Next  string `json:"next"`
+}
+
+type Index[K comparable, V any] struct {
+	Entries map[K]V `json:"entries"`
+}
+
+type Range[T cmp.Ordered] struct {
+	Min T `json:"min"`
+	Max T `json:"max"`
+}
+
+type Labeled[T fmt.Stringer] struct {
+	Value T `json:"value"`
+}
+
+type Tree[T any] struct {
+	Value    T         `json:"value"`
+	Children []Tree[T] `json:"children"`
+}
+
+type List[T any] []T
+
+type Orders struct {
+	Page  Page[Order]          `json:"page"`
+	Index Index[string, Order] `json:"index"`
+	Range Range[int]           `json:"range"`
+	Tree  *Tree[Order]         `json:"tree,omitempty"`
+	List  List[Order]          `json:"list"`
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+type Order struct {
+	ID string `json:"id"`
+}
+
+type Reader interface {
+	Get(ctx context.Context, id string) (*Order, error)
+}
+
+type OrderService interface {
+	Reader
+	fmt.Stringer
+
+	List(ctx context.Context, limit int, tags ...string) ([]Order, error)
+	Delete(id string) error
+	Stats() (int, float64)
+	Watch(onChange func(Order) bool)
+	Close()
+}
+
+type Payload interface{}
+
+type Shape interface {
+	Area() float64
+}
+
+type Drawing struct {
+	Label fmt.Stringer `json:"label"`
+	Shape Shape        `json:"shape"`
+}
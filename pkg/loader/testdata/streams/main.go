@@ -0,0 +1,22 @@
+package streams
+
+import (
+	"context"
+	"iter"
+)
+
+type Event struct {
+	Name string `json:"name"`
+}
+
+type EventSource interface {
+	Events(ctx context.Context) <-chan Event
+	All() iter.Seq[Event]
+	Indexed() iter.Seq2[int, Event]
+	Publish(events chan<- Event)
+	Pipe(events chan Event)
+}
+
+type Subscription struct {
+	Updates <-chan Event `json:"updates"`
+}
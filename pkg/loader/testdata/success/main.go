@@ -0,0 +1,53 @@
+package success
+
+import "time"
+
+type Status string
+
+const (
+	StatusOK      Status = "OK"
+	StatusFailure Status = "Failure"
+)
+
+type Priority int8
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+
+type Numbers struct {
+	Int     int
+	Int8    int8
+	Int16   int16
+	Int32   int32
+	Int64   int64
+	Uint    uint
+	Uint8   uint8
+	Uint16  uint16
+	Uint32  uint32
+	Uint64  uint64
+	Uintptr uintptr
+	Float32 float32
+	Float64 float64
+	Byte    byte
+	Rune    rune
+}
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type Data struct {
+	base
+
+	Status    Status            `json:"status"`
+	Priority  Priority          `json:"priority,omitempty"`
+	Tags      []string          `json:"tags"`
+	Hash      [4]uint8          `json:"hash"`
+	Labels    map[string]string `json:"labels"`
+	Next      *Data             `json:"next,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Ignored   string            `json:"-"`
+	private   string
+}
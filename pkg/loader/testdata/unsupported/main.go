@@ -0,0 +1,6 @@
+package unsupported
+
+type Order struct {
+	ID   string
+	Meta complex128
+}
@@ -0,0 +1,27 @@
+package wire
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+type Settings struct {
+	Timeout  time.Duration   `json:"timeout"`
+	Retry    *time.Duration  `json:"retry,omitempty"`
+	Payload  json.RawMessage `json:"payload"`
+	Amount   json.Number     `json:"amount"`
+	Balance  *big.Int        `json:"balance"`
+	Address  net.IP          `json:"address"`
+	Peer     netip.Addr      `json:"peer"`
+	Endpoint url.URL         `json:"endpoint"`
+	Callback *url.URL        `json:"callback,omitempty"`
+	Filter   *regexp.Regexp  `json:"filter"`
+	Backoff  []time.Duration `json:"backoff"`
+	Extra    json.RawMessage `json:"extra,omitempty"`
+	Gateway  net.IP          `json:"gateway,omitempty"`
+}
@@ -0,0 +1,143 @@
+package loader
+
+import (
+	"fmt"
+	"go/types"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+)
+
+// isError reports whether t is the built-in error interface
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isContext reports whether t is context.Context, which has no place in the Rust signatures
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	return named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// errorTrait returns the trait of the built-in error interface
+func errorTrait() *rstypes.Trait {
+	trait := &rstypes.Trait{Name: rstypes.ErrorTraitName}
+	trait.SetGoType("error")
+
+	return trait
+}
+
+// parseTrait converts the named interface t into a trait with its method set.
+// Traits always keep their names, since they can only be referred to by name.
+func (p *pkgLoader) parseTrait(t *types.Named, iface *types.Interface, exported bool) rstypes.Type {
+	trait := &rstypes.Trait{Name: t.String()}
+	trait.SetGoType(t.String())
+	trait.SetPosition(p.position(t.Obj().Pos()))
+
+	// For methods referring to the trait itself
+	p.parsing[t.String()] = trait
+	defer delete(p.parsing, t.String())
+
+	if exported {
+		p.types[t.String()] = trait
+	}
+
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		if super, ok := p.parseType(iface.EmbeddedType(i), true).(*rstypes.Trait); ok {
+			trait.Supertraits = append(trait.Supertraits, super)
+		}
+	}
+
+	for i := 0; i < iface.NumExplicitMethods(); i++ {
+		method := p.parseMethod(iface.ExplicitMethod(i))
+		method.Receiver = trait
+
+		trait.Methods = append(trait.Methods, method)
+	}
+
+	return trait
+}
+
+// parseMethod converts the interface method m into a function and reports unsupported types at the method
+func (p *pkgLoader) parseMethod(m *types.Func) (fn *rstypes.Function) {
+	defer func() {
+		if e := recover(); e != nil {
+			if u, ok := e.(*unsupportedTypeError); ok && u.field == "" {
+				u.kind = "method"
+				u.field = m.Name()
+				u.position = p.position(m.Pos())
+			}
+			panic(e)
+		}
+	}()
+
+	//nolint
+	sig := m.Type().(*types.Signature)
+
+	fn = p.parseSignature(sig)
+	fn.Name = m.Name()
+	fn.IsMethod = true
+	fn.SetPosition(p.position(m.Pos()))
+
+	return fn
+}
+
+// parseSignature converts a function signature.
+// Context parameters are dropped, and a trailing error result turns the results into a Result.
+func (p *pkgLoader) parseSignature(sig *types.Signature) *rstypes.Function {
+	fn := &rstypes.Function{}
+
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		v := params.At(i)
+		if isContext(v.Type()) {
+			continue
+		}
+
+		name := v.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+
+		// Arguments are passed as values, so pointers and nil slices are not optional
+		fn.Params = append(fn.Params, rstypes.FunctionParam{
+			Name:     name,
+			Type:     removeNullable(p.parseType(v.Type(), true)),
+			Position: p.position(v.Pos()),
+		})
+	}
+
+	results := sig.Results()
+	n := results.Len()
+	hasError := n != 0 && isError(results.At(n-1).Type())
+	if hasError {
+		n--
+	}
+
+	values := make([]rstypes.Type, 0, n)
+	for i := 0; i < n; i++ {
+		values = append(values, removeNullable(p.parseType(results.At(i).Type(), true)))
+	}
+
+	var ok rstypes.Type
+	switch len(values) {
+	case 0:
+		ok = &rstypes.Unit{}
+	case 1:
+		ok = values[0]
+	default:
+		ok = &rstypes.Tuple{Types: values}
+	}
+
+	switch {
+	case hasError:
+		fn.Returns = &rstypes.Result{Ok: ok, Err: errorTrait()}
+	case len(values) != 0:
+		fn.Returns = ok
+	}
+
+	return fn
+}
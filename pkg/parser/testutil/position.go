@@ -1,14 +0,0 @@
-// Package testutil offers test utility for parser
-package testutil
-
-import (
-	"go/token"
-
-	"github.com/go-generalize/go-easyparser/testutil"
-)
-
-// ParsePositionString parses position notations generated by token.Position.String()
-// Deprecated: github.com/go-generalize/go-easyparser/testutil.ParsePositionString
-func ParsePositionString(s string) *token.Position {
-	return testutil.ParsePositionString(s)
-}
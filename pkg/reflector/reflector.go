@@ -0,0 +1,249 @@
+// Package reflector converts Go types into rstypes at runtime with reflect
+package reflector
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+	"time"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"github.com/drewstone/go2rs/pkg/util"
+)
+
+const (
+	jsonTagOmitempty = "omitempty"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Reflector converts reflect.Type into rstypes.
+// Types converted by the same Reflector share their nodes.
+type Reflector struct {
+	// Overrides replaces the conversion of specific Go types
+	Overrides map[reflect.Type]rstypes.Type
+
+	converted map[reflect.Type]rstypes.Type
+}
+
+// NewReflector initializes a new Reflector
+func NewReflector() *Reflector {
+	return &Reflector{
+		Overrides: make(map[reflect.Type]rstypes.Type),
+		converted: make(map[reflect.Type]rstypes.Type),
+	}
+}
+
+// FromReflect converts t into rstypes with a new Reflector
+func FromReflect(t reflect.Type) (rstypes.Type, error) {
+	return NewReflector().FromReflect(t)
+}
+
+// FromReflect converts t into rstypes
+func (r *Reflector) FromReflect(t reflect.Type) (res rstypes.Type, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			var ok bool
+			err, ok = e.(error)
+
+			if !ok {
+				err = fmt.Errorf("%+v", e)
+			}
+		}
+	}()
+
+	if r.converted == nil {
+		r.converted = make(map[reflect.Type]rstypes.Type)
+	}
+
+	return r.convert(t), nil
+}
+
+// Types returns all named types converted so far by their fully qualified names
+func (r *Reflector) Types() map[string]rstypes.Type {
+	res := make(map[string]rstypes.Type, len(r.converted))
+	for t, typ := range r.converted {
+		res[TypeName(t)] = typ
+	}
+
+	return res
+}
+
+// TypeName returns the fully qualified name of t as used in rstypes, or "" for unnamed types
+func TypeName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return ""
+	}
+
+	return t.PkgPath() + "." + t.Name()
+}
+
+func (r *Reflector) convert(t reflect.Type) rstypes.Type {
+	if typ, ok := r.Overrides[t]; ok {
+		return typ
+	}
+	if typ, ok := r.converted[t]; ok {
+		return typ
+	}
+
+	if t == timeType {
+		date := &rstypes.Date{}
+		date.SetGoType(TypeName(t))
+
+		return date
+	}
+
+	name := TypeName(t)
+
+	var typ rstypes.Type
+	switch t.Kind() {
+	case reflect.Struct:
+		// Registered before the fields are converted for recursive references
+		strct := &rstypes.Struct{}
+		if name != "" {
+			r.converted[t] = strct
+		}
+
+		r.convertStruct(t, strct)
+		typ = strct
+	case reflect.Ptr:
+		typ = &rstypes.Nullable{Inner: r.convert(t.Elem())}
+	case reflect.Slice:
+		typ = &rstypes.Nullable{Inner: &rstypes.Vec{Inner: r.convert(t.Elem())}}
+	case reflect.Array:
+		typ = &rstypes.Array{Inner: r.convert(t.Elem()), Size: uint64(t.Len())}
+	case reflect.Map:
+		key := r.convert(t.Key())
+		if !key.UsedAsMapKey() {
+			panic(key.String() + " cannot be used as key")
+		}
+
+		typ = &rstypes.Nullable{Inner: &rstypes.Map{Key: key, Value: r.convert(t.Elem())}}
+	case reflect.Interface:
+		typ = &rstypes.Any{}
+	case reflect.Chan:
+		// Channels cannot be serialized, which the generator reports
+		stream := &rstypes.Stream{Inner: r.convert(t.Elem())}
+		switch t.ChanDir() {
+		case reflect.SendDir:
+			stream.Direction = rstypes.StreamSend
+		case reflect.BothDir:
+			stream.Direction = rstypes.StreamBoth
+		}
+		typ = stream
+	case reflect.Bool:
+		typ = &rstypes.Boolean{}
+	case reflect.String:
+		typ = &rstypes.String{}
+	default:
+		kind, ok := basicKinds[t.Kind()]
+		if !ok {
+			panic("unsupported type: " + t.String())
+		}
+
+		num := &rstypes.Number{}
+		num.SetRawType(kind)
+		typ = num
+	}
+
+	// Only named types are shared, as they are the only ones that can be referenced again
+	if name != "" {
+		if named, ok := typ.(rstypes.NamedType); ok {
+			named.SetName(name)
+		}
+		typ.SetPackageName(util.GetPackageNameFromPath(t.PkgPath()))
+		typ.SetGoType(name)
+
+		r.converted[t] = typ
+	}
+
+	return typ
+}
+
+var basicKinds = map[reflect.Kind]types.BasicKind{
+	reflect.Int:     types.Int,
+	reflect.Int8:    types.Int8,
+	reflect.Int16:   types.Int16,
+	reflect.Int32:   types.Int32,
+	reflect.Int64:   types.Int64,
+	reflect.Uint:    types.Uint,
+	reflect.Uint8:   types.Uint8,
+	reflect.Uint16:  types.Uint16,
+	reflect.Uint32:  types.Uint32,
+	reflect.Uint64:  types.Uint64,
+	reflect.Uintptr: types.Uintptr,
+	reflect.Float32: types.Float32,
+	reflect.Float64: types.Float64,
+}
+
+// jsonTag returns the field name and whether omitempty is set in the json tag
+func jsonTag(tag reflect.StructTag) (name string, omitempty bool) {
+	parts := strings.Split(tag.Get("json"), ",")
+
+	for _, opt := range parts[1:] {
+		if opt == jsonTagOmitempty {
+			omitempty = true
+		}
+	}
+
+	return parts[0], omitempty
+}
+
+func (r *Reflector) convertStruct(t reflect.Type, strct *rstypes.Struct) {
+	strct.Fields = make(map[string]rstypes.StructField)
+
+	idx := 0
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		name, optional := jsonTag(f.Tag)
+
+		if name == "-" {
+			continue
+		}
+
+		// Fields of embedded structs without a json name are promoted, which the generator resolves
+		embedded := false
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			embedded = ft.Kind() == reflect.Struct && ft != timeType
+		}
+
+		if !f.IsExported() && !embedded {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		typ := r.convert(f.Type)
+		if embedded {
+			optional = false
+		} else if nullable, ok := typ.(*rstypes.Nullable); ok && optional {
+			// Named slices and maps keep their Go type, which overrides and wire types are looked up by
+			if name := nullable.GetGoType(); name != "" && nullable.Inner.GetGoType() == "" {
+				nullable.Inner.SetGoType(name)
+			}
+			typ = nullable.Inner
+		}
+
+		strct.Fields[name] = rstypes.StructField{
+			RawName:    f.Name,
+			RawTag:     string(f.Tag),
+			FieldIndex: idx,
+			Type:       typ,
+			Optional:   optional,
+			Embedded:   embedded,
+		}
+		idx++
+	}
+}
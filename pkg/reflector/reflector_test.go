@@ -0,0 +1,174 @@
+package reflector
+
+import (
+	"encoding/json"
+	"go/types"
+	"net"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	rstypes "github.com/drewstone/go2rs/pkg/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type Status string
+
+type Base struct {
+	ID string `json:"id"`
+}
+
+type Item struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+}
+
+type Order struct {
+	Base
+
+	Status    Status            `json:"status"`
+	Items     []Item            `json:"items"`
+	Quantity  int32             `json:"quantity,omitempty"`
+	Note      *string           `json:"note,omitempty"`
+	Hash      [4]uint8          `json:"hash"`
+	Labels    map[string]string `json:"labels"`
+	Payload   []byte            `json:"payload"`
+	Meta      interface{}       `json:"meta"`
+	CreatedAt time.Time         `json:"created_at"`
+	Ignored   string            `json:"-"`
+	private   string
+}
+
+type Hook struct {
+	Extra   json.RawMessage `json:"extra,omitempty"`
+	Gateway net.IP          `json:"gateway,omitempty"`
+}
+
+type Tree struct {
+	Children []*Tree `json:"children"`
+	Parent   *Tree   `json:"parent"`
+}
+
+func number(kind types.BasicKind) *rstypes.Number {
+	num := &rstypes.Number{}
+	num.SetRawType(kind)
+
+	return num
+}
+
+func TestFromReflect(t *testing.T) {
+	const pkg = "github.com/drewstone/go2rs/pkg/reflector"
+
+	status := &rstypes.String{
+		Common: rstypes.Common{PkgName: "reflector", GoType: pkg + ".Status"},
+		Name:   pkg + ".Status",
+	}
+	item := &rstypes.Struct{
+		Common: rstypes.Common{PkgName: "reflector", GoType: pkg + ".Item"},
+		Name:   pkg + ".Item",
+		Fields: map[string]rstypes.StructField{
+			"sku":   {RawName: "SKU", RawTag: `json:"sku"`, FieldIndex: 0, Type: &rstypes.String{}},
+			"price": {RawName: "Price", RawTag: `json:"price"`, FieldIndex: 1, Type: number(types.Float64)},
+		},
+	}
+	base := &rstypes.Struct{
+		Common: rstypes.Common{PkgName: "reflector", GoType: pkg + ".Base"},
+		Name:   pkg + ".Base",
+		Fields: map[string]rstypes.StructField{
+			"id": {RawName: "ID", RawTag: `json:"id"`, FieldIndex: 0, Type: &rstypes.String{}},
+		},
+	}
+	want := &rstypes.Struct{
+		Common: rstypes.Common{PkgName: "reflector", GoType: pkg + ".Order"},
+		Name:   pkg + ".Order",
+		Fields: map[string]rstypes.StructField{
+			"Base":     {RawName: "Base", FieldIndex: 0, Type: base, Embedded: true},
+			"status":   {RawName: "Status", RawTag: `json:"status"`, FieldIndex: 1, Type: status},
+			"items":    {RawName: "Items", RawTag: `json:"items"`, FieldIndex: 2, Type: &rstypes.Nullable{Inner: &rstypes.Vec{Inner: item}}},
+			"quantity": {RawName: "Quantity", RawTag: `json:"quantity,omitempty"`, FieldIndex: 3, Type: number(types.Int32), Optional: true},
+			"note":     {RawName: "Note", RawTag: `json:"note,omitempty"`, FieldIndex: 4, Type: &rstypes.String{}, Optional: true},
+			"hash":     {RawName: "Hash", RawTag: `json:"hash"`, FieldIndex: 5, Type: &rstypes.Array{Inner: number(types.Uint8), Size: 4}},
+			"labels": {RawName: "Labels", RawTag: `json:"labels"`, FieldIndex: 6, Type: &rstypes.Nullable{
+				Inner: &rstypes.Map{Key: &rstypes.String{}, Value: &rstypes.String{}},
+			}},
+			"payload":    {RawName: "Payload", RawTag: `json:"payload"`, FieldIndex: 7, Type: &rstypes.Nullable{Inner: &rstypes.Vec{Inner: number(types.Uint8)}}},
+			"meta":       {RawName: "Meta", RawTag: `json:"meta"`, FieldIndex: 8, Type: &rstypes.Any{}},
+			"created_at": {RawName: "CreatedAt", RawTag: `json:"created_at"`, FieldIndex: 9, Type: &rstypes.Date{Common: rstypes.Common{GoType: "time.Time"}}},
+		},
+	}
+
+	got, err := FromReflect(reflect.TypeOf(Order{}))
+	if err != nil {
+		t.Fatalf("FromReflect() failed: %+v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FromReflect() differed: %s", diff)
+	}
+}
+
+func TestFromReflect_Recursive(t *testing.T) {
+	got, err := FromReflect(reflect.TypeOf(Tree{}))
+	if err != nil {
+		t.Fatalf("FromReflect() failed: %+v", err)
+	}
+
+	tree := got.(*rstypes.Struct)
+	parent, ok := tree.Fields["parent"].Type.(*rstypes.Nullable)
+	if !ok || parent.Inner != tree {
+		t.Errorf("parent is not a recursive reference: %v", tree.Fields["parent"].Type)
+	}
+}
+
+func TestFromReflect_OptionalNamedSlices(t *testing.T) {
+	got, err := FromReflect(reflect.TypeOf(Hook{}))
+	if err != nil {
+		t.Fatalf("FromReflect() failed: %+v", err)
+	}
+
+	// Optional slices are unwrapped from their Nullable without losing their names
+	hook := got.(*rstypes.Struct)
+	for field, goTypes := range map[string][]string{
+		"extra":   {"encoding/json.RawMessage", "encoding/json/jsontext.Value"},
+		"gateway": {"net.IP"},
+	} {
+		f := hook.Fields[field]
+		if !f.Optional || !slices.Contains(goTypes, f.Type.GetGoType()) {
+			t.Errorf("unexpected %s: %v with Go type %q", field, f.Type, f.Type.GetGoType())
+		}
+	}
+}
+
+func TestReflector_Overrides(t *testing.T) {
+	custom := &rstypes.Primitive{Name: "Custom"}
+
+	r := NewReflector()
+	r.Overrides[reflect.TypeOf(Item{})] = custom
+
+	got, err := r.FromReflect(reflect.TypeOf([]Item{}))
+	if err != nil {
+		t.Fatalf("FromReflect() failed: %+v", err)
+	}
+
+	if inner := got.(*rstypes.Nullable).Inner.(*rstypes.Vec).Inner; inner != custom {
+		t.Errorf("override was not used: %v", inner)
+	}
+}
+
+func TestFromReflect_Unsupported(t *testing.T) {
+	if _, err := FromReflect(reflect.TypeOf(complex128(0))); err == nil {
+		t.Errorf("expected an error for complex128")
+	}
+}
+
+func TestFromReflect_Chan(t *testing.T) {
+	got, err := FromReflect(reflect.TypeOf(make(chan<- int)))
+	if err != nil {
+		t.Fatalf("FromReflect() failed: %+v", err)
+	}
+
+	if stream, ok := got.(*rstypes.Stream); !ok || stream.Direction != rstypes.StreamSend {
+		t.Errorf("expected a send-only stream: %v", got)
+	}
+}
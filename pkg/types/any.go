@@ -1,7 +1,7 @@
 // Package types contains structs/interfaces representing Rust types
 package rstypes
 
-// Any represents a dynamic type in Rust (dyn Any)
+// Any represents a dynamic value of Go's interface{} or any, which is serde_json::Value by default
 type Any struct {
 	Common
 }
@@ -9,12 +9,12 @@ type Any struct {
 var _ Type = &Any{}
 
 // UsedAsMapKey returns whether this type can be used as the key for map.
-// dyn Any cannot be used as a map key since it doesn't implement Hash/Eq.
+// Dynamic values cannot be used as map keys since they don't implement Hash/Eq.
 func (a *Any) UsedAsMapKey() bool {
 	return false
 }
 
 // String returns this type in string representation
 func (a *Any) String() string {
-	return "dyn Any"
+	return "serde_json::Value"
 }
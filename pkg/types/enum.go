@@ -13,12 +13,36 @@ type EnumVariant struct {
 	Position *token.Position
 }
 
+// Tagging is how serde represents which variant of an enum a value is
+type Tagging int
+
+const (
+	// TaggingDefault uses the tagging chosen by the generator
+	TaggingDefault Tagging = iota
+	// TaggingExternal wraps the content in an object keyed by the variant: {"Circle": {...}}
+	TaggingExternal
+	// TaggingInternal adds the variant to the content as the Tag field: {"kind": "Circle", ...}
+	TaggingInternal
+	// TaggingAdjacent puts the variant and the content side by side: {"kind": "Circle", "data": {...}}
+	TaggingAdjacent
+	// TaggingUntagged tries every variant in order without any tag
+	TaggingUntagged
+)
+
 // Enum - enum in Rust
 type Enum struct {
 	Common
 	Name string
 
+	// Variants are keyed by the Rust variant names and ordered by FieldIndex.
+	// The name in the json key of RawTag, the Go struct tag, is the name of the variant on the wire if it differs from the key.
+	// Type is nil for unit variants.
 	Variants map[string]EnumVariant
+
+	// Tagging, Tag and Content override the tagging of the generator for this enum
+	Tagging Tagging
+	Tag     string
+	Content string
 }
 
 var _ Type = &Enum{}
@@ -85,3 +85,41 @@ func (e *Number) String() string {
 	}
 	return fmt.Sprintf("u%d", e.BitSize)
 }
+
+// SetRawType sets the Go basic kind and derives the Rust numeric type from it.
+// int, uint and uintptr are platform sized and become isize/usize.
+func (e *Number) SetRawType(kind types.BasicKind) {
+	e.RawType = kind
+	e.IsFloat = false
+	e.IsSigned = false
+	e.IsUnsized = false
+
+	switch kind {
+	case types.Int, types.UntypedInt, types.UntypedRune:
+		e.IsSigned, e.IsUnsized, e.BitSize = true, true, 64
+	case types.Uint, types.Uintptr:
+		e.IsUnsized, e.BitSize = true, 64
+	case types.Int8:
+		e.IsSigned, e.BitSize = true, 8
+	case types.Int16:
+		e.IsSigned, e.BitSize = true, 16
+	case types.Int32:
+		e.IsSigned, e.BitSize = true, 32
+	case types.Int64:
+		e.IsSigned, e.BitSize = true, 64
+	case types.Uint8:
+		e.BitSize = 8
+	case types.Uint16:
+		e.BitSize = 16
+	case types.Uint32:
+		e.BitSize = 32
+	case types.Uint64:
+		e.BitSize = 64
+	case types.Float32:
+		e.IsFloat, e.IsSigned, e.BitSize = true, true, 32
+	case types.Float64, types.UntypedFloat:
+		e.IsFloat, e.IsSigned, e.BitSize = true, true, 64
+	default:
+		panic(fmt.Sprintf("unsupported numeric kind: %s", types.Typ[kind].Name()))
+	}
+}
@@ -0,0 +1,29 @@
+// Package types contains structs/interfaces representing Rust types
+package rstypes
+
+// TypeParam - type parameter of a generic struct in Rust, e.g. T in Page<T>
+type TypeParam struct {
+	Common
+	Name string
+
+	// Constraint is the Go constraint as written, e.g. "any", "comparable" or "cmp.Ordered"
+	Constraint string
+	// Comparable is set when the constraint only allows comparable types
+	Comparable bool
+	// Methods are the names of the methods the constraint requires
+	Methods []string
+	// Union is set when the constraint restricts the types to a union like ~int | ~string
+	Union bool
+}
+
+var _ Type = &TypeParam{}
+
+// UsedAsMapKey returns whether this type can be used as the key for map
+func (p *TypeParam) UsedAsMapKey() bool {
+	return p.Comparable
+}
+
+// String returns this type in string representation
+func (p *TypeParam) String() string {
+	return p.Name
+}
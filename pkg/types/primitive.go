@@ -1,7 +1,9 @@
 // Package types contains structs/interfaces representing Rust types
 package rstypes
 
-// Primitive represents primitive types in Rust
+// Primitive represents any Rust type written by hand, e.g. "uuid::Uuid" or "HashMap<String, rust_decimal::Decimal>".
+// The generator imports the paths in it whose names do not collide with others and refers to them by their names,
+// and writes the other paths in full.
 type Primitive struct {
 	Common
 	Name string
@@ -9,10 +11,12 @@ type Primitive struct {
 
 var _ Type = &Primitive{}
 
+// UsedAsMapKey returns whether this type can be used as the key for map
 func (p *Primitive) UsedAsMapKey() bool {
 	return true
 }
 
+// String returns this type in string representation
 func (p *Primitive) String() string {
 	return p.Name
 }
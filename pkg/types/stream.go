@@ -1,11 +1,24 @@
 // Package types contains structs/interfaces representing Rust types
 package rstypes
 
+// StreamDirection is the direction of the Go channel a stream is derived from
+type StreamDirection int
+
+const (
+	// StreamReceive is a receive-only channel or an iterator, which the holder reads from
+	StreamReceive StreamDirection = iota
+	// StreamSend is a send-only channel
+	StreamSend
+	// StreamBoth is a bidirectional channel
+	StreamBoth
+)
+
 // Stream - stream in Rust
 type Stream struct {
 	Common
-	Name  string
-	Inner Type
+	Name      string
+	Inner     Type
+	Direction StreamDirection
 }
 
 var _ Type = &Stream{}
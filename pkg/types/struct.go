@@ -1,7 +1,10 @@
 // Package types contains structs/interfaces representing Rust types
 package rstypes
 
-import "go/token"
+import (
+	"go/token"
+	"strings"
+)
 
 // StructField is a field in structs
 type StructField struct {
@@ -12,6 +15,9 @@ type StructField struct {
 	Type     Type
 	Position *token.Position
 	Optional bool
+	// Embedded is set on embedded structs without a json name, whose fields are promoted into the struct.
+	// The field is keyed by its Go name, and Type is a Nullable when the struct is embedded by pointer.
+	Embedded bool
 }
 
 // Struct - struct in Rust
@@ -20,6 +26,11 @@ type Struct struct {
 	Name string
 
 	Fields map[string]StructField
+
+	// TypeParams are the type parameters of a generic struct declaration
+	TypeParams []*TypeParam
+	// TypeArgs are set on references to instances of a generic struct, which is declared under Name
+	TypeArgs []Type
 }
 
 var _ Type = &Struct{}
@@ -37,5 +48,14 @@ func (n *Struct) SetName(name string) {
 
 // String returns this type in string representation
 func (n *Struct) String() string {
-	return n.Name
+	if len(n.TypeArgs) == 0 {
+		return n.Name
+	}
+
+	args := make([]string, 0, len(n.TypeArgs))
+	for _, arg := range n.TypeArgs {
+		args = append(args, arg.String())
+	}
+
+	return n.Name + "<" + strings.Join(args, ", ") + ">"
 }
@@ -1,19 +1,37 @@
 // Package types contains structs/interfaces representing Rust types
 package rstypes
 
+// ErrorTraitName is the name of the trait for Go's built-in error interface
+const ErrorTraitName = "error"
+
 // Trait - trait in Rust
 type Trait struct {
 	Common
 	Name string
+	// Methods are the methods declared by the trait itself
+	Methods []*Function
+	// Supertraits are the traits of the embedded interfaces
+	Supertraits []*Trait
 }
 
 var _ Type = &Trait{}
+var _ NamedType = &Trait{}
 
 // UsedAsMapKey returns whether this type can be used as the key for map
 func (t *Trait) UsedAsMapKey() bool {
 	return false
 }
 
+// SetName sets an alternative name
+func (t *Trait) SetName(name string) {
+	t.Name = name
+}
+
+// IsError returns whether this trait is Go's built-in error interface
+func (t *Trait) IsError() bool {
+	return t.Name == ErrorTraitName
+}
+
 // String returns this type in string representation
 func (t *Trait) String() string {
 	return "trait " + t.Name
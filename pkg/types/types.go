@@ -9,6 +9,8 @@ type Common struct {
 	// Currently, only exported types in the root package is available.
 	PkgName  string
 	Position *token.Position
+	// GoType is the fully qualified name of the Go named type this type was derived from, if any
+	GoType string
 }
 
 // SetPackageName sets PkgName in Common
@@ -31,6 +33,16 @@ func (c *Common) GetPosition() *token.Position {
 	return c.Position
 }
 
+// SetGoType sets GoType in Common
+func (c *Common) SetGoType(goType string) {
+	c.GoType = goType
+}
+
+// GetGoType returns GoType in Common
+func (c *Common) GetGoType() string {
+	return c.GoType
+}
+
 // Type interface represents all Rust types handled by go-easyparser
 type Type interface {
 	SetPackageName(pkgName string)
@@ -39,6 +51,8 @@ type Type interface {
 	String() string
 	SetPosition(pos *token.Position)
 	GetPosition() *token.Position
+	SetGoType(goType string)
+	GetGoType() string
 }
 
 // Enumerable interface represents union types